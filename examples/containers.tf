@@ -10,10 +10,10 @@ resource "dockhand_container" "web" {
     "443:443"
   ]
 
-  env = [
-    "NGINX_HOST=example.com",
-    "NGINX_PORT=80"
-  ]
+  env = {
+    NGINX_HOST = "example.com"
+    NGINX_PORT = "80"
+  }
 
   labels = {
     app     = "web"
@@ -21,7 +21,7 @@ resource "dockhand_container" "web" {
     env     = "production"
   }
 
-  memory = 536870912 # 512MB in bytes
+  memory = "512m"
   cpus   = 1.0
 }
 
@@ -32,18 +32,21 @@ resource "dockhand_container" "database" {
   image          = "postgres:15-alpine"
   restart_policy = "always"
 
-  env = [
-    "POSTGRES_USER=admin",
-    "POSTGRES_PASSWORD=secretpassword",
-    "POSTGRES_DB=myapp"
-  ]
+  env = {
+    POSTGRES_USER = "admin"
+    POSTGRES_DB   = "myapp"
+  }
+
+  env_sensitive = {
+    POSTGRES_PASSWORD = var.postgres_password
+  }
 
   labels = {
     app  = "database"
     tier = "backend"
   }
 
-  memory = 1073741824 # 1GB in bytes
+  memory = "1g"
   cpus   = 2.0
 }
 
@@ -59,6 +62,6 @@ resource "dockhand_container" "cache" {
     tier = "data"
   }
 
-  memory = 268435456 # 256MB in bytes
+  memory = "256m"
   cpus   = 0.5
 }
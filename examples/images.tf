@@ -55,3 +55,13 @@ resource "dockhand_container" "web_from_pulled" {
     deployment = "standard"
   }
 }
+
+# Example: Promote a pulled image to the internal registry
+resource "dockhand_image_tag" "nginx_promoted" {
+  environment_id = dockhand_environment.local.id
+  source_image   = dockhand_image_pull.nginx.image
+  target         = "registry.company.com/platform/nginx:stable"
+
+  push     = true
+  registry = "registry.company.com"
+}
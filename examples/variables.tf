@@ -19,6 +19,13 @@ variable "registry_password" {
   default     = ""
 }
 
+variable "postgres_password" {
+  description = "Password for the example PostgreSQL container"
+  type        = string
+  sensitive   = true
+  default     = ""
+}
+
 variable "github_username" {
   description = "GitHub username for GitHub Container Registry authentication"
   type        = string
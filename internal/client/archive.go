@@ -0,0 +1,53 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"strings"
+	"time"
+)
+
+// ArchiveFile is a single file written into a tar archive by BuildArchive
+type ArchiveFile struct {
+	Path    string // Absolute destination path inside the container
+	Content []byte
+	Mode    int64
+	UID     int
+	GID     int
+	Uname   string
+	Gname   string
+}
+
+// BuildArchive builds a tar archive of files, to be extracted at "/" with
+// UploadArchive
+func BuildArchive(files []ArchiveFile) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	for _, f := range files {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     strings.TrimPrefix(f.Path, "/"),
+			Size:     int64(len(f.Content)),
+			Mode:     f.Mode,
+			Uid:      f.UID,
+			Gid:      f.GID,
+			Uname:    f.Uname,
+			Gname:    f.Gname,
+			ModTime:  time.Now(),
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
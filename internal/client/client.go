@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"time"
@@ -47,10 +48,11 @@ func (c *Client) GetHTTPClient() *resty.Client {
 
 // Container operations
 
-// ListContainers retrieves all containers
-func (c *Client) ListContainers(environmentID string) ([]Container, error) {
+// ListContainers retrieves all containers matching filter, which may be nil
+func (c *Client) ListContainers(environmentID string, filter *ListFilter) ([]Container, error) {
 	var containers []Container
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(filter.Values()).
 		SetResult(&containers).
 		Get(fmt.Sprintf("/api/environments/%s/containers", environmentID))
 
@@ -121,9 +123,10 @@ func (c *Client) UpdateContainer(environmentID, containerID string, container *C
 	return &result, nil
 }
 
-// DeleteContainer deletes a container
-func (c *Client) DeleteContainer(environmentID, containerID string) error {
+// DeleteContainer deletes a container. opts may be nil
+func (c *Client) DeleteContainer(environmentID, containerID string, opts *DeleteContainerOptions) error {
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(opts.Values()).
 		Delete(fmt.Sprintf("/api/environments/%s/containers/%s", environmentID, containerID))
 
 	if err != nil {
@@ -153,9 +156,10 @@ func (c *Client) StartContainer(environmentID, containerID string) error {
 	return nil
 }
 
-// StopContainer stops a container
-func (c *Client) StopContainer(environmentID, containerID string) error {
+// StopContainer stops a container. opts may be nil
+func (c *Client) StopContainer(environmentID, containerID string, opts *StopOptions) error {
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(opts.Values()).
 		Post(fmt.Sprintf("/api/environments/%s/containers/%s/stop", environmentID, containerID))
 
 	if err != nil {
@@ -217,12 +221,192 @@ func (c *Client) UnpauseContainer(environmentID, containerID string) error {
 	return nil
 }
 
+// UploadArchive extracts a tar archive into a container at path
+func (c *Client) UploadArchive(environmentID, containerID, path string, archive []byte) error {
+	resp, err := c.httpClient.R().
+		SetQueryParam("path", path).
+		SetHeader("Content-Type", "application/x-tar").
+		SetBody(archive).
+		Put(fmt.Sprintf("/api/environments/%s/containers/%s/archive", environmentID, containerID))
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to upload archive: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// GetContainerLogs retrieves the logs of a container selected by opts, which may be nil
+func (c *Client) GetContainerLogs(environmentID, containerID string, opts *ContainerLogsOptions) (string, error) {
+	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(opts.Values()).
+		Get(fmt.Sprintf("/api/environments/%s/containers/%s/logs", environmentID, containerID))
+
+	if err != nil {
+		return "", err
+	}
+
+	if !resp.IsSuccess() {
+		return "", fmt.Errorf("failed to get container logs: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp.String(), nil
+}
+
+// GetContainerStats retrieves a single resource usage snapshot of a container
+func (c *Client) GetContainerStats(environmentID, containerID string) (*ContainerStats, error) {
+	var stats ContainerStats
+	resp, err := c.httpClient.R().
+		SetQueryParam("stream", "false").
+		SetResult(&stats).
+		Get(fmt.Sprintf("/api/environments/%s/containers/%s/stats", environmentID, containerID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("failed to get container stats: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return &stats, nil
+}
+
+// CreateExec creates an exec instance in a container and returns its ID
+func (c *Client) CreateExec(environmentID, containerID string, exec *ExecConfig) (string, error) {
+	var result struct {
+		ID string `json:"id"`
+	}
+	resp, err := c.httpClient.R().
+		SetBody(exec).
+		SetResult(&result).
+		Post(fmt.Sprintf("/api/environments/%s/containers/%s/exec", environmentID, containerID))
+
+	if err != nil {
+		return "", err
+	}
+
+	if !resp.IsSuccess() {
+		return "", fmt.Errorf("failed to create exec: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return result.ID, nil
+}
+
+// Delays between two checks of a running exec, doubling from the first to the
+// second
+var (
+	execPollInterval    = 250 * time.Millisecond
+	maxExecPollInterval = 5 * time.Second
+)
+
+// StartExec starts an exec instance and waits for its output. The request is
+// only bounded by ctx, not by the client timeout, since commands such as
+// migrations may run for a long time
+func (c *Client) StartExec(ctx context.Context, environmentID, execID string) (*ExecResult, error) {
+	var result ExecResult
+	resp, err := c.withoutTimeout().httpClient.R().
+		SetContext(ctx).
+		SetBody(map[string]bool{"detach": false}).
+		SetResult(&result).
+		Post(fmt.Sprintf("/api/environments/%s/exec/%s/start", environmentID, execID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("failed to start exec: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	result.ID = execID
+	return &result, nil
+}
+
+// InspectExec retrieves the state of an exec instance
+func (c *Client) InspectExec(environmentID, execID string) (*ExecInspect, error) {
+	var inspect ExecInspect
+	resp, err := c.httpClient.R().
+		SetResult(&inspect).
+		Get(fmt.Sprintf("/api/environments/%s/exec/%s", environmentID, execID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("failed to inspect exec: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return &inspect, nil
+}
+
+// RunExec runs a command in a container to completion, capturing stdout,
+// stderr and the exit code. It gives up when ctx is done
+func (c *Client) RunExec(ctx context.Context, environmentID, containerID string, exec *ExecConfig) (*ExecResult, error) {
+	execID, err := c.CreateExec(environmentID, containerID, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.StartExec(ctx, environmentID, execID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The start response carries the output; the exit code is only final
+	// once the exec is no longer running.
+	inspect, err := c.waitExec(ctx, environmentID, execID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ExitCode = inspect.ExitCode
+	return result, nil
+}
+
+// waitExec polls an exec instance with increasing delays until it is no
+// longer running
+func (c *Client) waitExec(ctx context.Context, environmentID, execID string) (*ExecInspect, error) {
+	interval := execPollInterval
+	for {
+		inspect, err := c.InspectExec(environmentID, execID)
+		if err != nil {
+			return nil, err
+		}
+		if !inspect.Running {
+			return inspect, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("exec %s is still running: %w", execID, ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxExecPollInterval)
+	}
+}
+
+// withoutTimeout returns a client for the same endpoint whose requests are
+// only bounded by their context
+func (c *Client) withoutTimeout() *Client {
+	config := *c.config
+	config.Timeout = 0
+
+	return NewClient(&config)
+}
+
 // Compose Stack operations
 
-// ListComposeStacks retrieves all compose stacks
-func (c *Client) ListComposeStacks(environmentID string) ([]ComposeStack, error) {
+// ListComposeStacks retrieves all compose stacks matching filter, which may be nil
+func (c *Client) ListComposeStacks(environmentID string, filter *ListFilter) ([]ComposeStack, error) {
 	var stacks []ComposeStack
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(filter.Values()).
 		SetResult(&stacks).
 		Get(fmt.Sprintf("/api/environments/%s/compose-stacks", environmentID))
 
@@ -293,9 +477,10 @@ func (c *Client) UpdateComposeStack(environmentID, stackID string, stack *Compos
 	return &result, nil
 }
 
-// DeleteComposeStack deletes a compose stack
-func (c *Client) DeleteComposeStack(environmentID, stackID string) error {
+// DeleteComposeStack deletes a compose stack. opts may be nil
+func (c *Client) DeleteComposeStack(environmentID, stackID string, opts *DeleteComposeStackOptions) error {
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(opts.Values()).
 		Delete(fmt.Sprintf("/api/environments/%s/compose-stacks/%s", environmentID, stackID))
 
 	if err != nil {
@@ -325,9 +510,10 @@ func (c *Client) StartComposeStack(environmentID, stackID string) error {
 	return nil
 }
 
-// StopComposeStack stops a compose stack
-func (c *Client) StopComposeStack(environmentID, stackID string) error {
+// StopComposeStack stops a compose stack. opts may be nil
+func (c *Client) StopComposeStack(environmentID, stackID string, opts *StopOptions) error {
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(opts.Values()).
 		Post(fmt.Sprintf("/api/environments/%s/compose-stacks/%s/stop", environmentID, stackID))
 
 	if err != nil {
@@ -341,12 +527,86 @@ func (c *Client) StopComposeStack(environmentID, stackID string) error {
 	return nil
 }
 
+// SyncComposeStack pulls the Git repository of a compose stack at ref, or at
+// the head of its branch when ref is empty, and redeploys it
+func (c *Client) SyncComposeStack(environmentID, stackID, ref string) (*ComposeStack, error) {
+	var result ComposeStack
+	resp, err := c.httpClient.R().
+		SetBody(&GitSyncRequest{Ref: ref}).
+		SetResult(&result).
+		Post(fmt.Sprintf("/api/environments/%s/compose-stacks/%s/git/sync", environmentID, stackID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("failed to sync compose stack: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return &result, nil
+}
+
+// RestartComposeStack restarts the services of a compose stack
+func (c *Client) RestartComposeStack(environmentID, stackID string) error {
+	resp, err := c.httpClient.R().
+		Post(fmt.Sprintf("/api/environments/%s/compose-stacks/%s/restart", environmentID, stackID))
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to restart compose stack: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// PullComposeStack pulls the images of all services of a compose stack
+// without recreating them, and reports each image's digest before and after
+func (c *Client) PullComposeStack(environmentID, stackID string) ([]ComposeImagePull, error) {
+	var result []ComposeImagePull
+	resp, err := c.httpClient.R().
+		SetResult(&result).
+		Post(fmt.Sprintf("/api/environments/%s/compose-stacks/%s/pull", environmentID, stackID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("failed to pull compose stack images: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return result, nil
+}
+
+// RecreateComposeServices recreates the containers of the given services of a
+// compose stack, leaving the other services running
+func (c *Client) RecreateComposeServices(environmentID, stackID string, services []string) error {
+	resp, err := c.httpClient.R().
+		SetBody(&ComposeRecreateRequest{Services: services}).
+		Post(fmt.Sprintf("/api/environments/%s/compose-stacks/%s/recreate", environmentID, stackID))
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to recreate compose services: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
 // Environment operations
 
-// ListEnvironments retrieves all environments
-func (c *Client) ListEnvironments() ([]Environment, error) {
+// ListEnvironments retrieves all environments matching filter, which may be nil
+func (c *Client) ListEnvironments(filter *ListFilter) ([]Environment, error) {
 	var environments []Environment
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(filter.Values()).
 		SetResult(&environments).
 		Get("/api/environments")
 
@@ -435,10 +695,11 @@ func (c *Client) DeleteEnvironment(environmentID string) error {
 
 // Network operations
 
-// ListNetworks retrieves all networks
-func (c *Client) ListNetworks(environmentID string) ([]Network, error) {
+// ListNetworks retrieves all networks matching filter, which may be nil
+func (c *Client) ListNetworks(environmentID string, filter *ListFilter) ([]Network, error) {
 	var networks []Network
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(filter.Values()).
 		SetResult(&networks).
 		Get(fmt.Sprintf("/api/environments/%s/networks", environmentID))
 
@@ -508,10 +769,11 @@ func (c *Client) DeleteNetwork(environmentID, networkID string) error {
 
 // Volume operations
 
-// ListVolumes retrieves all volumes
-func (c *Client) ListVolumes(environmentID string) ([]Volume, error) {
+// ListVolumes retrieves all volumes matching filter, which may be nil
+func (c *Client) ListVolumes(environmentID string, filter *ListFilter) ([]Volume, error) {
 	var volumes []Volume
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(filter.Values()).
 		SetResult(&volumes).
 		Get(fmt.Sprintf("/api/environments/%s/volumes", environmentID))
 
@@ -581,10 +843,11 @@ func (c *Client) DeleteVolume(environmentID, volumeID string) error {
 
 // Image operations
 
-// ListImages retrieves all images
-func (c *Client) ListImages(environmentID string) ([]Image, error) {
+// ListImages retrieves all images matching filter, which may be nil
+func (c *Client) ListImages(environmentID string, filter *ListFilter) ([]Image, error) {
 	var images []Image
 	resp, err := c.httpClient.R().
+		SetQueryParamsFromValues(filter.Values()).
 		SetResult(&images).
 		Get(fmt.Sprintf("/api/environments/%s/images", environmentID))
 
@@ -634,6 +897,57 @@ func (c *Client) PullImage(environmentID string, pullReq *ImagePullRequest) erro
 	return nil
 }
 
+// TagImage tags an existing image with a new repository and tag
+func (c *Client) TagImage(environmentID string, tagReq *ImageTagRequest) error {
+	resp, err := c.httpClient.R().
+		SetBody(tagReq).
+		Post(fmt.Sprintf("/api/environments/%s/images/tag", environmentID))
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to tag image: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// UntagImage removes a repository tag from an image without deleting the underlying image
+func (c *Client) UntagImage(environmentID, reference string) error {
+	resp, err := c.httpClient.R().
+		SetBody(map[string]string{"image": reference}).
+		Post(fmt.Sprintf("/api/environments/%s/images/untag", environmentID))
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to untag image: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// PushImage pushes an image to a registry
+func (c *Client) PushImage(environmentID string, pushReq *ImagePushRequest) error {
+	resp, err := c.httpClient.R().
+		SetBody(pushReq).
+		Post(fmt.Sprintf("/api/environments/%s/images/push", environmentID))
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to push image: %d %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
 // DeleteImage deletes an image
 func (c *Client) DeleteImage(environmentID, imageID string) error {
 	resp, err := c.httpClient.R().
@@ -1,7 +1,17 @@
 package client
 
 import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewClientSetsCookieHeader(t *testing.T) {
@@ -19,3 +29,121 @@ func TestNewClientSetsCookieHeader(t *testing.T) {
 		t.Fatalf("expected Cookie header to be set, got %q", headers.Get("Cookie"))
 	}
 }
+
+func TestListFilterValues(t *testing.T) {
+	var nilFilter *ListFilter
+	if v := nilFilter.Values(); len(v) != 0 {
+		t.Fatalf("expected no values for nil filter, got %v", v)
+	}
+
+	f := &ListFilter{
+		Name:   "web",
+		State:  "running",
+		Labels: map[string]string{"tier": "frontend", "app": "shop"},
+	}
+	v := f.Values()
+	if v.Get("name") != "web" || v.Get("state") != "running" {
+		t.Fatalf("unexpected values: %v", v)
+	}
+	labels := v["label"]
+	if len(labels) != 2 || labels[0] != "app=shop" || labels[1] != "tier=frontend" {
+		t.Fatalf("expected sorted label values, got %v", labels)
+	}
+}
+
+func TestContainerLogsOptionsValues(t *testing.T) {
+	var nilOpts *ContainerLogsOptions
+	v := nilOpts.Values()
+	if v.Get("stdout") != "true" || v.Get("stderr") != "true" || v.Has("tail") {
+		t.Fatalf("unexpected defaults: %v", v)
+	}
+
+	opts := &ContainerLogsOptions{Tail: 50, Since: "10m", Timestamps: true, Stderr: true}
+	v = opts.Values()
+	if v.Get("tail") != "50" || v.Get("since") != "10m" || v.Get("timestamps") != "true" || v.Get("stdout") != "false" {
+		t.Fatalf("unexpected values: %v", v)
+	}
+}
+
+func TestStopAndDeleteOptionsValues(t *testing.T) {
+	var nilStop *StopOptions
+	if v := nilStop.Values(); len(v) != 0 {
+		t.Fatalf("expected no values, got %v", v)
+	}
+
+	timeout := 30
+	v := (&StopOptions{Timeout: &timeout, Signal: "SIGQUIT"}).Values()
+	if v.Get("t") != "30" || v.Get("signal") != "SIGQUIT" {
+		t.Fatalf("unexpected stop values: %v", v)
+	}
+
+	v = (&DeleteContainerOptions{Force: true, RemoveVolumes: true}).Values()
+	if v.Get("force") != "true" || v.Get("v") != "true" {
+		t.Fatalf("unexpected container delete values: %v", v)
+	}
+
+	v = (&DeleteComposeStackOptions{RemoveOrphans: true, RemoveImages: "local"}).Values()
+	if v.Get("remove_orphans") != "true" || v.Has("volumes") || v.Get("rmi") != "local" {
+		t.Fatalf("unexpected stack delete values: %v", v)
+	}
+}
+
+func TestBuildArchive(t *testing.T) {
+	archive, err := BuildArchive([]ArchiveFile{
+		{Path: "/etc/app/config.yml", Content: []byte("debug: true\n"), Mode: 0o640, UID: 1000, GID: 1000},
+	})
+	if err != nil {
+		t.Fatalf("BuildArchive returned error: %v", err)
+	}
+
+	tr := tar.NewReader(bytes.NewReader(archive))
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("reading archive: %v", err)
+	}
+	if hdr.Name != "etc/app/config.yml" || hdr.Mode != 0o640 || hdr.Uid != 1000 || hdr.Size != 12 {
+		t.Fatalf("unexpected header: %+v", hdr)
+	}
+
+	content, _ := io.ReadAll(tr)
+	if string(content) != "debug: true\n" {
+		t.Fatalf("unexpected content: %q", content)
+	}
+}
+
+func TestRunExecWaitsForCompletion(t *testing.T) {
+	execPollInterval = time.Millisecond
+
+	inspections := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/exec"):
+			fmt.Fprint(w, `{"id":"e1"}`)
+		case strings.HasSuffix(r.URL.Path, "/start"):
+			// Longer than the client timeout
+			time.Sleep(1500 * time.Millisecond)
+			fmt.Fprint(w, `{"stdout":"migrated\n"}`)
+		default:
+			inspections++
+			fmt.Fprintf(w, `{"id":"e1","running":%t,"exit_code":3}`, inspections < 3)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(&Config{Endpoint: srv.URL, Timeout: 1})
+	result, err := c.RunExec(context.Background(), "1", "web", &ExecConfig{Cmd: []string{"migrate"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Stdout != "migrated\n" || result.ExitCode != 3 || inspections != 3 {
+		t.Fatalf("unexpected result %+v after %d inspections", result, inspections)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	inspections = -1000
+	if _, err := c.RunExec(ctx, "1", "web", &ExecConfig{Cmd: []string{"migrate"}}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+}
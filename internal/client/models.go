@@ -1,21 +1,261 @@
 package client
 
+import (
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// ListFilter narrows the results of a list call. Filters are sent as query
+// parameters; servers that do not support a parameter ignore it, so callers
+// should still check the results.
+type ListFilter struct {
+	Name    string
+	Labels  map[string]string
+	State   string
+	Image   string
+	Network string
+}
+
+// Values encodes the filter as query parameters. Labels are sent as repeated
+// "label" parameters of the form key=value. A nil filter encodes to no
+// parameters.
+func (f *ListFilter) Values() url.Values {
+	values := url.Values{}
+	if f == nil {
+		return values
+	}
+
+	if f.Name != "" {
+		values.Set("name", f.Name)
+	}
+	if f.State != "" {
+		values.Set("state", f.State)
+	}
+	if f.Image != "" {
+		values.Set("image", f.Image)
+	}
+	if f.Network != "" {
+		values.Set("network", f.Network)
+	}
+
+	keys := make([]string, 0, len(f.Labels))
+	for k := range f.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values.Add("label", k+"="+f.Labels[k])
+	}
+
+	return values
+}
+
 // Container represents a Docker container
 type Container struct {
-	ID      string            `json:"id"`
-	Name    string            `json:"name"`
-	Image   string            `json:"image"`
-	State   string            `json:"state"`
-	Status  string            `json:"status"`
-	Ports   []ContainerPort   `json:"ports,omitempty"`
-	Mounts  []ContainerMount  `json:"mounts,omitempty"`
-	Env     []string          `json:"env,omitempty"`
-	Labels  map[string]string `json:"labels,omitempty"`
-	Command string            `json:"command,omitempty"`
-	Args    []string          `json:"args,omitempty"`
-	Memory  int64             `json:"memory,omitempty"`
-	CPUs    float64           `json:"cpus,omitempty"`
-	Restart string            `json:"restart_policy,omitempty"`
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	Image             string            `json:"image"`
+	State             string            `json:"state"`
+	Status            string            `json:"status"`
+	Ports             []ContainerPort   `json:"ports,omitempty"`
+	Mounts            []ContainerMount  `json:"mounts,omitempty"`
+	Env               []string          `json:"env"` // Always sent, so an empty list clears the environment
+	Labels            map[string]string `json:"labels,omitempty"`
+	Command           string            `json:"command,omitempty"`
+	Args              []string          `json:"args,omitempty"`
+	Memory            int64             `json:"memory,omitempty"`
+	CPUs              float64           `json:"cpus,omitempty"`
+	Restart           string            `json:"restart_policy,omitempty"`
+	Networks          []string          `json:"networks,omitempty"`
+	Created           string            `json:"created,omitempty"`
+	Start             *bool             `json:"start,omitempty"` // Create only; nil starts the container
+	Entrypoint        []string          `json:"entrypoint,omitempty"`
+	User              string            `json:"user,omitempty"`
+	WorkingDir        string            `json:"working_dir,omitempty"`
+	Hostname          string            `json:"hostname,omitempty"`
+	Domainname        string            `json:"domainname,omitempty"`
+	DNS               []string          `json:"dns,omitempty"`
+	DNSSearch         []string          `json:"dns_search,omitempty"`
+	ExtraHosts        []string          `json:"extra_hosts,omitempty"`
+	Tty               bool              `json:"tty,omitempty"`
+	OpenStdin         bool              `json:"stdin_open,omitempty"`
+	StopSignal        string            `json:"stop_signal,omitempty"`
+	StopTimeout       *int              `json:"stop_timeout,omitempty"`
+	CapAdd            []string          `json:"cap_add,omitempty"`
+	CapDrop           []string          `json:"cap_drop,omitempty"`
+	Privileged        bool              `json:"privileged,omitempty"`
+	ReadOnly          bool              `json:"read_only,omitempty"`
+	SecurityOpt       []string          `json:"security_opt,omitempty"`
+	UsernsMode        string            `json:"userns_mode,omitempty"`
+	Sysctls           map[string]string `json:"sysctls,omitempty"`
+	MemoryReservation int64             `json:"memory_reservation,omitempty"`
+	MemorySwap        int64             `json:"memory_swap,omitempty"`
+	CPUShares         int64             `json:"cpu_shares,omitempty"`
+	CpusetCpus        string            `json:"cpuset_cpus,omitempty"`
+	PidsLimit         *int64            `json:"pids_limit,omitempty"`
+	ShmSize           int64             `json:"shm_size,omitempty"`
+	Ulimits           []Ulimit          `json:"ulimits,omitempty"`
+	LogConfig         *LogConfig        `json:"log_config,omitempty"`
+}
+
+// LogConfig represents the logging driver configuration of a container
+type LogConfig struct {
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// Ulimit represents a resource limit applied to container processes
+type Ulimit struct {
+	Name string `json:"name"`
+	Soft int64  `json:"soft"`
+	Hard int64  `json:"hard"`
+}
+
+// ContainerLogsOptions selects which container log lines are returned
+type ContainerLogsOptions struct {
+	Tail       int    // Number of lines from the end of the logs, 0 for all
+	Since      string // RFC 3339 timestamp or relative duration such as "10m"
+	Timestamps bool
+	Stdout     bool
+	Stderr     bool
+}
+
+// Values returns the options as query parameters. A nil receiver selects
+// all lines from both streams.
+func (o *ContainerLogsOptions) Values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		o = &ContainerLogsOptions{Stdout: true, Stderr: true}
+	}
+
+	if o.Tail > 0 {
+		values.Set("tail", strconv.Itoa(o.Tail))
+	}
+	if o.Since != "" {
+		values.Set("since", o.Since)
+	}
+	values.Set("timestamps", strconv.FormatBool(o.Timestamps))
+	values.Set("stdout", strconv.FormatBool(o.Stdout))
+	values.Set("stderr", strconv.FormatBool(o.Stderr))
+
+	return values
+}
+
+// StopOptions controls how a container or compose stack is stopped
+type StopOptions struct {
+	Timeout *int   // Seconds to wait before killing, nil for the daemon default
+	Signal  string // Signal sent first, empty for the container default
+}
+
+// Values returns the options as query parameters. A nil receiver uses the
+// defaults.
+func (o *StopOptions) Values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+
+	if o.Timeout != nil {
+		values.Set("t", strconv.Itoa(*o.Timeout))
+	}
+	if o.Signal != "" {
+		values.Set("signal", o.Signal)
+	}
+
+	return values
+}
+
+// DeleteContainerOptions controls how a container is removed
+type DeleteContainerOptions struct {
+	Force         bool // Kill the container if it is still running
+	RemoveVolumes bool // Remove anonymous volumes attached to the container
+}
+
+// Values returns the options as query parameters. A nil receiver uses the
+// defaults.
+func (o *DeleteContainerOptions) Values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+
+	if o.Force {
+		values.Set("force", "true")
+	}
+	if o.RemoveVolumes {
+		values.Set("v", "true")
+	}
+
+	return values
+}
+
+// DeleteComposeStackOptions controls how a compose stack is torn down
+type DeleteComposeStackOptions struct {
+	RemoveOrphans bool   // Remove containers for services not in the compose file
+	RemoveVolumes bool   // Remove named and anonymous volumes
+	RemoveImages  string // "all" or "local" to remove service images, empty to keep them
+}
+
+// Values returns the options as query parameters. A nil receiver uses the
+// defaults.
+func (o *DeleteComposeStackOptions) Values() url.Values {
+	values := url.Values{}
+	if o == nil {
+		return values
+	}
+
+	if o.RemoveOrphans {
+		values.Set("remove_orphans", "true")
+	}
+	if o.RemoveVolumes {
+		values.Set("volumes", "true")
+	}
+	if o.RemoveImages != "" {
+		values.Set("rmi", o.RemoveImages)
+	}
+
+	return values
+}
+
+// ContainerStats represents a single resource usage snapshot of a container
+type ContainerStats struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	CPUPercent      float64 `json:"cpu_percent"`
+	MemoryUsage     int64   `json:"memory_usage"`
+	MemoryLimit     int64   `json:"memory_limit"`
+	MemoryPercent   float64 `json:"memory_percent"`
+	NetworkRxBytes  int64   `json:"network_rx_bytes"`
+	NetworkTxBytes  int64   `json:"network_tx_bytes"`
+	BlockReadBytes  int64   `json:"block_read_bytes"`
+	BlockWriteBytes int64   `json:"block_write_bytes"`
+	PIDs            int64   `json:"pids"`
+}
+
+// ExecConfig describes a command to run inside a container
+type ExecConfig struct {
+	Cmd          []string `json:"cmd"`
+	Env          []string `json:"env,omitempty"`
+	User         string   `json:"user,omitempty"`
+	WorkingDir   string   `json:"working_dir,omitempty"`
+	AttachStdout bool     `json:"attach_stdout"`
+	AttachStderr bool     `json:"attach_stderr"`
+}
+
+// ExecResult holds the captured output and exit code of a finished exec
+type ExecResult struct {
+	ID       string `json:"id"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// ExecInspect represents the state of an exec instance
+type ExecInspect struct {
+	ID       string `json:"id"`
+	Running  bool   `json:"running"`
+	ExitCode int    `json:"exit_code"`
 }
 
 // ContainerPort represents a container port mapping
@@ -36,18 +276,22 @@ type ContainerMount struct {
 
 // ComposeStack represents a Docker Compose stack
 type ComposeStack struct {
-	ID            string                    `json:"id"`
-	Name          string                    `json:"name"`
-	Compose       string                    `json:"compose"`
-	Status        string                    `json:"status"`
-	DesiredStatus string                    `json:"desired_status,omitempty"`
-	Services      map[string]ComposeService `json:"services,omitempty"`
-	Labels        map[string]string         `json:"labels,omitempty"`
-	GitRepo       *GitRepository            `json:"git_repo,omitempty"`
-	AutoSync      bool                      `json:"auto_sync,omitempty"`
-	WebhookToken  string                    `json:"webhook_token,omitempty"`
-	CreatedAt     string                    `json:"created_at,omitempty"`
-	UpdatedAt     string                    `json:"updated_at,omitempty"`
+	ID             string                    `json:"id"`
+	Name           string                    `json:"name"`
+	Compose        string                    `json:"compose"`
+	Status         string                    `json:"status"`
+	DesiredStatus  string                    `json:"desired_status,omitempty"`
+	Services       map[string]ComposeService `json:"services,omitempty"`
+	Labels         map[string]string         `json:"labels,omitempty"`
+	Env            map[string]string         `json:"env,omitempty"`
+	EnvFile        string                    `json:"env_file,omitempty"`
+	PullPolicy     string                    `json:"pull_policy,omitempty"` // "always", "missing" or "never"
+	GitRepo        *GitRepository            `json:"git_repo,omitempty"`
+	AutoSync       bool                      `json:"auto_sync,omitempty"`
+	WebhookToken   string                    `json:"webhook_token,omitempty"`
+	DeployedCommit string                    `json:"deployed_commit,omitempty"`
+	CreatedAt      string                    `json:"created_at,omitempty"`
+	UpdatedAt      string                    `json:"updated_at,omitempty"`
 }
 
 // ComposeService represents a service in a Compose stack
@@ -58,14 +302,33 @@ type ComposeService struct {
 	Count  int    `json:"count,omitempty"`
 }
 
+// ComposeImagePull reports the image of a service before and after a pull
+type ComposeImagePull struct {
+	Service        string `json:"service"`
+	Image          string `json:"image"`
+	PreviousDigest string `json:"previous_digest,omitempty"`
+	Digest         string `json:"digest,omitempty"`
+}
+
+// ComposeRecreateRequest selects the services of a stack to recreate
+type ComposeRecreateRequest struct {
+	Services []string `json:"services"`
+}
+
 // GitRepository represents a Git repository configuration
 type GitRepository struct {
 	URL    string   `json:"url"`
 	Branch string   `json:"branch,omitempty"`
 	Path   string   `json:"path,omitempty"`
+	Ref    string   `json:"ref,omitempty"` // Branch, tag or commit to deploy instead of the branch head
 	Auth   *GitAuth `json:"auth,omitempty"`
 }
 
+// GitSyncRequest selects the Git reference a stack is synced to
+type GitSyncRequest struct {
+	Ref string `json:"ref,omitempty"` // Branch, tag or commit; empty for the configured branch head
+}
+
 // GitAuth represents Git authentication
 type GitAuth struct {
 	Type  string `json:"type"` // "ssh" or "https"
@@ -171,3 +434,18 @@ type ImageAuth struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// ImageTagRequest represents a request to tag an image
+type ImageTagRequest struct {
+	Source     string `json:"source"`
+	Repository string `json:"repository"`
+	Tag        string `json:"tag,omitempty"`
+}
+
+// ImagePushRequest represents an image push request. When Registry names a
+// registry stored in Dockhand its credentials are used unless Auth is set.
+type ImagePushRequest struct {
+	Image    string     `json:"image"`
+	Registry string     `json:"registry,omitempty"`
+	Auth     *ImageAuth `json:"auth,omitempty"`
+}
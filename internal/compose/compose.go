@@ -0,0 +1,144 @@
+// Package compose parses, validates, merges and converts Docker Compose documents.
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlErrorRegexp extracts the line number from a YAML syntax error.
+var yamlErrorRegexp = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// Issue describes a problem found in a compose document.
+type Issue struct {
+	Line    int    // 1-based line number, 0 when unknown
+	Column  int    // 1-based column number, 0 when unknown
+	Path    string // Location in the document, such as "services.web.ports[0]"
+	Message string
+}
+
+// Error formats the issue with its location.
+func (i Issue) Error() string {
+	msg := i.Message
+	if i.Path != "" {
+		msg = i.Path + ": " + msg
+	}
+	if i.Line > 0 {
+		msg = fmt.Sprintf("line %d: %s", i.Line, msg)
+	}
+
+	return msg
+}
+
+// CheckSyntax returns an issue when content is not a YAML mapping, without
+// checking it against the Compose specification. It suits partial documents
+// such as override files.
+func CheckSyntax(content []byte) *Issue {
+	_, issue := parse(content)
+	return issue
+}
+
+// parse decodes content into a YAML node tree and returns the root mapping.
+func parse(content []byte) (*yaml.Node, *Issue) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		issue := &Issue{Message: err.Error()}
+		if m := yamlErrorRegexp.FindStringSubmatch(err.Error()); m != nil {
+			issue.Line, _ = strconv.Atoi(m[1])
+			issue.Message = m[2]
+		}
+		return nil, issue
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, &Issue{Message: "the compose file is empty"}
+	}
+
+	root := resolve(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, issueAt(root, "", "the compose file must be a mapping")
+	}
+
+	return root, nil
+}
+
+// issueAt returns an issue located at node n.
+func issueAt(n *yaml.Node, path, format string, args ...any) *Issue {
+	return &Issue{
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// resolve follows YAML aliases to the node they refer to.
+func resolve(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	return n
+}
+
+// pair is a key and value of a YAML mapping.
+type pair struct {
+	Key   *yaml.Node
+	Value *yaml.Node
+}
+
+// pairs returns the entries of a mapping node in document order, expanding
+// "<<" merge keys. Explicit keys take precedence over merged ones.
+func pairs(n *yaml.Node) []pair {
+	n = resolve(n)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var explicit, merged []pair
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], resolve(n.Content[i+1])
+		if key.Value == "<<" && key.Tag == "!!merge" {
+			sources := []*yaml.Node{value}
+			if value.Kind == yaml.SequenceNode {
+				sources = value.Content
+			}
+			for _, src := range sources {
+				merged = append(merged, pairs(src)...)
+			}
+			continue
+		}
+		explicit = append(explicit, pair{key, value})
+		seen[key.Value] = true
+	}
+
+	result := explicit
+	for _, p := range merged {
+		if !seen[p.Key.Value] {
+			result = append(result, p)
+			seen[p.Key.Value] = true
+		}
+	}
+
+	return result
+}
+
+// lookup returns the value of key in mapping n, or nil when it is not set.
+func lookup(n *yaml.Node, key string) *yaml.Node {
+	for _, p := range pairs(n) {
+		if p.Key.Value == key {
+			return p.Value
+		}
+	}
+
+	return nil
+}
+
+// isNull reports whether n is absent or an explicit YAML null.
+func isNull(n *yaml.Node) bool {
+	return n == nil || (n.Kind == yaml.ScalarNode && n.Tag == "!!null")
+}
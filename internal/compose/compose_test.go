@@ -0,0 +1,388 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateValid(t *testing.T) {
+	content := `
+x-common: &common
+  restart: unless-stopped
+services:
+  web:
+    <<: *common
+    image: nginx:latest
+    ports:
+      - "80:80"
+      - "127.0.0.1:8443:443/tcp"
+      - target: 9000
+        published: "9000"
+    networks: [frontend]
+    volumes:
+      - data:/data
+      - ./conf:/etc/nginx/conf.d:ro
+    depends_on:
+      db:
+        condition: service_healthy
+  db:
+    image: postgres:15
+    networks:
+      - frontend
+networks:
+  frontend:
+volumes:
+  data:
+    driver: local
+`
+	if issues := Validate([]byte(content)); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestValidateIssues(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		line    int
+		message string
+	}{
+		{"syntax", "services:\n  web:\n    image: nginx: latest\n", 3, "mapping values are not allowed"},
+		{"not a mapping", "- web\n", 1, "must be a mapping"},
+		{"no services", "networks: {}\n", 1, "services must be defined"},
+		{"unknown top-level key", "services:\n  web:\n    image: nginx\nservice: {}\n", 4, `unsupported key "service"`},
+		{"unknown service key", "services:\n  web:\n    image: nginx\n    imagee: nginx\n", 4, `unsupported key "imagee"`},
+		{"no image", "services:\n  web:\n    command: run\n", 2, "must set image or build"},
+		{"bad port", "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:eighty\"\n", 5, `invalid port "80:eighty"`},
+		{"undefined network", "services:\n  web:\n    image: nginx\n    networks: [back]\n", 4, `network "back" is not defined`},
+		{"undefined volume", "services:\n  web:\n    image: nginx\n    volumes:\n      - data:/data\n", 5, `volume "data" is not defined`},
+		{"undefined dependency", "services:\n  web:\n    image: nginx\n    depends_on: [db]\n", 4, `undefined service "db"`},
+		{"bad condition", "services:\n  web:\n    image: nginx\n    depends_on:\n      db:\n        condition: healthy\n  db:\n    image: postgres\n", 6, `invalid condition "healthy"`},
+		{"cycle", "services:\n  a:\n    image: x\n    depends_on: [b]\n  b:\n    image: x\n    depends_on: [a]\n", 7, "dependency cycle: a -> b -> a"},
+	}
+
+	for _, tc := range cases {
+		issues := Validate([]byte(tc.content))
+		if len(issues) != 1 {
+			t.Fatalf("%s: expected one issue, got %v", tc.name, issues)
+		}
+		if issues[0].Line != tc.line || !strings.Contains(issues[0].Error(), tc.message) {
+			t.Fatalf("%s: unexpected issue %q at line %d", tc.name, issues[0].Error(), issues[0].Line)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := "services:\n  web:\n    image: nginx\n    ports: [\"80:80\"]\n    environment:\n      PORT: 80\n"
+	b := "# the web tier\nservices:\n  web:\n    environment: {PORT: 80}\n    ports:\n      - 80:80\n    image: 'nginx'\n"
+	if !Equal(a, b) {
+		t.Fatal("expected reformatted documents to be equal")
+	}
+
+	if Equal(a, strings.Replace(a, "PORT: 80", `PORT: "80"`, 1)) {
+		t.Fatal("expected a number and a string to differ")
+	}
+	if Equal("services:\n  web:\n    tty: true\n", "services:\n  web:\n    tty: \"true\"\n") {
+		t.Fatal("expected a boolean and a string to differ")
+	}
+
+	if Equal(a, strings.Replace(a, "nginx", "caddy", 1)) {
+		t.Fatal("expected documents with different images to differ")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := "services:\n  web:\n    image: nginx:1.25\n    ports: [\"80:80\"]\n  cache:\n    image: redis\nvolumes:\n  data: {}\n"
+	new := "services:\n  web:\n    image: nginx:1.27\n    ports: [\"80:80\"]\n    restart: always\n  db:\n    image: postgres\n"
+
+	changes, err := Diff(old, new)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+
+	want := []string{
+		`service "cache" removed`,
+		`service "db" added`,
+		`service "web" changed: image, restart`,
+		`volumes removed`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+
+	changes, err = Diff("services:\n  web:\n    tty: true\n", "services:\n  web:\n    tty: \"true\"\n")
+	if err != nil || len(changes) != 1 || changes[0].String() != `service "web" changed: tty` {
+		t.Fatalf("expected a type change to be reported, got %v (%v)", changes, err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := `
+services:
+  web:
+    image: nginx:1.25
+    command: ["nginx", "-g", "daemon off;"]
+    ports: ["80:80"]
+    environment:
+      - LOG_LEVEL=info
+      - TZ=UTC
+    volumes:
+      - data:/data
+      - ./conf:/etc/nginx/conf.d
+  debug:
+    image: busybox
+    profiles: [debug]
+volumes:
+  data: {}
+`
+	override := `
+services:
+  web:
+    image: nginx:1.27
+    command: ["nginx-debug"]
+    ports: ["443:443"]
+    environment:
+      LOG_LEVEL: debug
+    volumes:
+      - ./conf.prod:/etc/nginx/conf.d:ro
+`
+
+	doc, err := Merge([]byte(base), []byte(override))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	web := doc["services"].(map[string]any)["web"].(map[string]any)
+	if web["image"] != "nginx:1.27" {
+		t.Fatalf("expected the image to be replaced, got %v", web["image"])
+	}
+	if cmd := web["command"].([]any); len(cmd) != 1 || cmd[0] != "nginx-debug" {
+		t.Fatalf("expected command to be replaced, got %v", cmd)
+	}
+	if ports := web["ports"].([]any); len(ports) != 2 {
+		t.Fatalf("expected ports to be concatenated, got %v", ports)
+	}
+	env := web["environment"].(map[string]any)
+	if env["LOG_LEVEL"] != "debug" || env["TZ"] != "UTC" {
+		t.Fatalf("expected environment to be merged by key, got %v", env)
+	}
+	volumes := web["volumes"].([]any)
+	if len(volumes) != 2 || volumes[1] != "./conf.prod:/etc/nginx/conf.d:ro" {
+		t.Fatalf("expected volumes to be merged by target, got %v", volumes)
+	}
+
+	ApplyProfiles(doc, nil)
+	if names := ServiceNames(doc); strings.Join(names, ",") != "web" {
+		t.Fatalf("expected the debug service to be removed, got %v", names)
+	}
+
+	doc, _ = Merge([]byte(base))
+	ApplyProfiles(doc, []string{"debug"})
+	debug := doc["services"].(map[string]any)["debug"].(map[string]any)
+	if _, ok := debug["profiles"]; ok {
+		t.Fatal("expected profiles to be removed from active services")
+	}
+
+	out, err := Marshal(doc)
+	if err != nil || !Equal(out, strings.Replace(base, "    profiles: [debug]\n", "", 1)) {
+		t.Fatalf("unexpected merged document %q: %v", out, err)
+	}
+
+	for _, deps := range []string{"[debug, db]", "{debug: {condition: service_started}, db: {condition: service_healthy}}"} {
+		doc, _ = Merge([]byte("services:\n  web:\n    image: nginx\n    depends_on: " + deps + "\n  db:\n    image: postgres\n  debug:\n    image: busybox\n    profiles: [debug]\n"))
+		ApplyProfiles(doc, nil)
+
+		out, err := Marshal(doc)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if issues := Validate([]byte(out)); len(issues) > 0 {
+			t.Fatalf("expected depends_on %s to drop the debug service, got %v", deps, issues)
+		}
+		if !strings.Contains(out, "db") || strings.Contains(out, "debug") {
+			t.Fatalf("expected depends_on %s to keep only db, got %q", deps, out)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	content := `
+services:
+  web:
+    image: nginx:latest
+    restart: unless-stopped
+    ports:
+      - "80:80"
+      - target: 443
+        published: 8443
+    volumes:
+      - data:/usr/share/nginx/html:ro
+    environment:
+      - NGINX_HOST=example.com
+      - HOME
+    labels:
+      com.example.tier: frontend
+    depends_on: [db]
+    networks: [frontend]
+  db:
+    image: postgres:16
+    environment:
+      POSTGRES_PASSWORD: ${DB_PASSWORD}
+  app:
+    build: .
+networks:
+  frontend:
+    driver: bridge
+volumes:
+  data: {}
+`
+
+	result, err := Convert([]byte(content), ConvertOptions{EnvironmentID: "dockhand_environment.local.id"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `resource "dockhand_container" "web" {
+  environment_id = dockhand_environment.local.id
+  name           = "web"
+  image          = "nginx:latest"
+  restart_policy = "unless-stopped"
+  ports          = ["80:80", "8443:443"]
+  mounts         = ["${dockhand_volume.data.name}:/usr/share/nginx/html:ro"]
+
+  env = {
+    NGINX_HOST = "example.com"
+  }
+
+  labels = {
+    "com.example.tier" = "frontend"
+  }
+
+  depends_on = [dockhand_container.db]
+}
+`
+	if !strings.HasSuffix(result.HCL, want) {
+		t.Fatalf("unexpected web container:\n%s", result.HCL)
+	}
+	for _, s := range []string{`resource "dockhand_network" "frontend"`, `resource "dockhand_volume" "data"`, `"$${DB_PASSWORD}"`} {
+		if !strings.Contains(result.HCL, s) {
+			t.Fatalf("expected %s in:\n%s", s, result.HCL)
+		}
+	}
+	if strings.Contains(result.HCL, `"app"`) {
+		t.Fatalf("expected the built service to be left out:\n%s", result.HCL)
+	}
+
+	var paths []string
+	for _, issue := range result.Unsupported {
+		paths = append(paths, issue.Path)
+	}
+	if got := strings.Join(paths, ", "); got != "services.app, services.db.environment, services.web.environment.HOME, services.web.networks" {
+		t.Fatalf("unexpected unsupported features: %s", got)
+	}
+
+	if _, err := Convert([]byte("services:\n  web: {}\n"), ConvertOptions{}); err == nil {
+		t.Fatal("expected an error for an invalid compose file")
+	}
+}
+
+func TestConvertResourceLimits(t *testing.T) {
+	content := `
+services:
+  web:
+    image: nginx
+    mem_limit: 512m
+    mem_reservation: 256m
+    memswap_limit: 1g
+    cpu_shares: 512
+    cpuset: "0-1"
+    userns_mode: host
+    ulimits:
+      nproc: 65535
+      nofile:
+        soft: 20000
+        hard: 40000
+`
+
+	result, err := Convert([]byte(content), ConvertOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Unsupported) > 0 {
+		t.Fatalf("unexpected unsupported features: %v", result.Unsupported)
+	}
+
+	for _, s := range []string{
+		`memory             = "512m"`,
+		`memory_reservation = "256m"`,
+		`memory_swap        = "1g"`,
+		`cpu_shares         = 512`,
+		`cpuset_cpus        = "0-1"`,
+		`userns_mode        = "host"`,
+		"name = \"nofile\"\n    soft = 20000\n    hard = 40000",
+		"name = \"nproc\"\n    soft = 65535\n    hard = 65535",
+	} {
+		if !strings.Contains(result.HCL, s) {
+			t.Fatalf("expected %s in:\n%s", s, result.HCL)
+		}
+	}
+}
+
+func TestConvertUniqueLabels(t *testing.T) {
+	content := `
+services:
+  web.app:
+    image: nginx
+    volumes: ["app-data:/data"]
+    depends_on: [web_app]
+  web_app:
+    image: redis
+    volumes: ["app_data:/data"]
+volumes:
+  app-data: {}
+  app_data: {}
+`
+
+	result, err := Convert([]byte(content), ConvertOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, s := range []string{
+		`resource "dockhand_container" "web_app" {`,
+		`resource "dockhand_container" "web_app_2" {`,
+		`depends_on     = [dockhand_container.web_app_2]`,
+		`resource "dockhand_volume" "app-data" {`,
+		`resource "dockhand_volume" "app_data" {`,
+		`mounts         = ["${dockhand_volume.app_data.name}:/data"]`,
+	} {
+		if !strings.Contains(result.HCL, s) {
+			t.Fatalf("expected %s in:\n%s", s, result.HCL)
+		}
+	}
+
+	result, _ = Convert([]byte("services:\n  a.b:\n    image: nginx\n  a_b:\n    image: nginx\n"), ConvertOptions{})
+	if strings.Count(result.HCL, `"a_b"`) != 2 || !strings.Contains(result.HCL, `"a_b_2"`) {
+		t.Fatalf("expected colliding names to get distinct labels:\n%s", result.HCL)
+	}
+}
+
+func TestConvertInterpolatedNumbers(t *testing.T) {
+	content := "services:\n  web:\n    image: nginx\n    cpus: ${CPUS}\n    pids_limit: 100\n    ulimits:\n      nofile: ${NOFILE}\n"
+
+	result, err := Convert([]byte(content), ConvertOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, s := range []string{`cpus           = "$${CPUS}"`, `pids_limit     = 100`, `soft = "$${NOFILE}"`} {
+		if !strings.Contains(result.HCL, s) {
+			t.Fatalf("expected %s in:\n%s", s, result.HCL)
+		}
+	}
+}
@@ -0,0 +1,577 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConvertOptions configures the conversion of a compose document.
+type ConvertOptions struct {
+	// EnvironmentID is the HCL expression assigned to environment_id, such
+	// as `dockhand_environment.local.id`. Defaults to `var.environment_id`.
+	EnvironmentID string
+}
+
+// Conversion is the result of converting a compose document.
+type Conversion struct {
+	HCL         string  // dockhand_network, dockhand_volume and dockhand_container resources
+	Unsupported []Issue // Compose features that were not converted
+}
+
+// containerAttrOrder is the order of the attributes of a converted
+// dockhand_container.
+var containerAttrOrder = []string{
+	"environment_id", "name", "image", "restart_policy", "command", "args", "entrypoint",
+	"user", "working_dir", "hostname", "domainname", "tty", "stdin_open", "stop_signal", "stop_timeout",
+	"privileged", "read_only", "security_opts", "userns_mode", "memory", "memory_reservation", "memory_swap",
+	"cpus", "cpu_shares", "cpuset_cpus", "pids_limit", "shm_size", "ulimits",
+	"dns", "dns_search", "extra_hosts", "ports", "mounts", "log_driver", "log_opts",
+	"env", "labels", "sysctls", "capabilities", "depends_on",
+}
+
+// ignoredKeys are keys that are dropped silently during conversion, since
+// they have no effect on the resulting resources.
+var ignoredKeys = keySet("version", "name", "container_name")
+
+// Convert translates a compose document into dockhand_network,
+// dockhand_volume and dockhand_container resources. Features without an
+// equivalent in these resources are left out and reported, as are services
+// that cannot be converted at all, such as services that are built.
+func Convert(content []byte, opts ConvertOptions) (*Conversion, error) {
+	if issues := Validate(content); len(issues) > 0 {
+		messages := make([]string, len(issues))
+		for i, issue := range issues {
+			messages[i] = issue.Error()
+		}
+		return nil, fmt.Errorf("invalid compose file:\n  - %s", strings.Join(messages, "\n  - "))
+	}
+
+	doc, err := Merge(content)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &converter{
+		environmentID: hclExpr(opts.EnvironmentID),
+		volumes:       map[string]string{},
+		labels:        map[string]string{},
+		usedLabels:    map[string]bool{},
+	}
+	if c.environmentID == "" {
+		c.environmentID = "var.environment_id"
+	}
+
+	for _, key := range sortedKeys(doc) {
+		switch {
+		case key == "services" || key == "networks" || key == "volumes" || ignoredKeys[key] || strings.HasPrefix(key, "x-"):
+		default:
+			c.unsupported(key, "top-level %q is not supported", key)
+		}
+	}
+
+	networks, _ := doc["networks"].(map[string]any)
+	for _, name := range sortedKeys(networks) {
+		c.convertNetwork(name, networks[name])
+	}
+
+	volumes, _ := doc["volumes"].(map[string]any)
+	for _, name := range sortedKeys(volumes) {
+		c.convertVolume(name, volumes[name])
+	}
+
+	services, _ := doc["services"].(map[string]any)
+	for _, name := range sortedKeys(services) {
+		c.convertService(name, services[name], services)
+	}
+
+	blocks := make([]string, len(c.blocks))
+	for i, b := range c.blocks {
+		blocks[i] = b.String()
+	}
+
+	return &Conversion{
+		HCL:         strings.Join(blocks, "\n\n") + "\n",
+		Unsupported: c.issues,
+	}, nil
+}
+
+// converter holds the state of a conversion.
+type converter struct {
+	environmentID hclExpr
+	blocks        []*hclBlock
+	issues        []Issue
+	volumes       map[string]string // Compose volume name to the template of its name
+	labels        map[string]string // Resource type and compose name to resource name
+	usedLabels    map[string]bool   // Resource type and resource name
+}
+
+// unsupported reports a feature at path that is not converted.
+func (c *converter) unsupported(path, format string, args ...any) {
+	c.issues = append(c.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// label returns the resource name of a compose object. Names that would
+// collide with another resource of the same type, such as "web.app" and
+// "web_app", get a numeric suffix.
+func (c *converter) label(resourceType, name string) string {
+	if label, ok := c.labels[resourceType+"."+name]; ok {
+		return label
+	}
+
+	base := hclLabel(name)
+	label := base
+	for i := 2; c.usedLabels[resourceType+"."+label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	c.labels[resourceType+"."+name] = label
+	c.usedLabels[resourceType+"."+label] = true
+
+	return label
+}
+
+// block adds a resource block for a compose object.
+func (c *converter) block(resourceType, name string) *hclBlock {
+	b := &hclBlock{Header: fmt.Sprintf("resource %q %q", resourceType, c.label(resourceType, name))}
+	b.set("environment_id", c.environmentID)
+	c.blocks = append(c.blocks, b)
+
+	return b
+}
+
+// convertNetwork converts a top-level network into a dockhand_network.
+func (c *converter) convertNetwork(name string, value any) {
+	path := "networks." + name
+	m, _ := value.(map[string]any)
+
+	if isTrue(m["external"]) {
+		c.unsupported(path, "external networks are not converted; they must already exist")
+		return
+	}
+
+	b := c.block("dockhand_network", name)
+	b.set("name", stringOr(m["name"], name))
+
+	for _, key := range sortedKeys(m) {
+		switch key {
+		case "name":
+		case "driver":
+			b.set("driver", fmt.Sprint(m[key]))
+		case "labels":
+			b.set("labels", stringMap(m[key]))
+		default:
+			c.unsupportedKey(path, key, "dockhand_network")
+		}
+	}
+}
+
+// convertVolume converts a top-level volume into a dockhand_volume.
+func (c *converter) convertVolume(name string, value any) {
+	path := "volumes." + name
+	m, _ := value.(map[string]any)
+
+	if isTrue(m["external"]) {
+		c.volumes[name] = hclEscape(stringOr(m["name"], name))
+		c.unsupported(path, "external volumes are not converted; they must already exist")
+		return
+	}
+
+	b := c.block("dockhand_volume", name)
+	b.set("name", stringOr(m["name"], name))
+	c.volumes[name] = fmt.Sprintf("${dockhand_volume.%s.name}", c.label("dockhand_volume", name))
+
+	for _, key := range sortedKeys(m) {
+		switch key {
+		case "name":
+		case "driver":
+			b.set("driver", fmt.Sprint(m[key]))
+		case "driver_opts":
+			b.set("options", stringMap(m[key]))
+		case "labels":
+			b.set("labels", stringMap(m[key]))
+		default:
+			c.unsupportedKey(path, key, "dockhand_volume")
+		}
+	}
+}
+
+// convertService converts a service into a dockhand_container.
+func (c *converter) convertService(name string, value any, services map[string]any) {
+	path := "services." + name
+	m, _ := value.(map[string]any)
+
+	if _, ok := m["image"]; !ok {
+		c.unsupported(path, "services without an image are not converted; build the image and set image")
+		return
+	}
+
+	b := c.block("dockhand_container", name)
+	b.set("name", stringOr(m["container_name"], name))
+	b.set("image", fmt.Sprint(m["image"]))
+
+	var capabilities []hclAttr
+	var dependsOn []hclExpr
+
+	for _, key := range sortedKeys(m) {
+		v := m[key]
+		keyPath := path + "." + key
+
+		if hasInterpolation(v) {
+			c.unsupported(keyPath, "variable interpolation is not resolved; the value is used literally")
+		}
+
+		switch key {
+		case "image", "container_name":
+		case "restart":
+			b.set("restart_policy", fmt.Sprint(v))
+		case "command":
+			if args := stringList(v); len(args) > 0 {
+				if _, ok := v.(string); ok {
+					b.set("command", fmt.Sprint(v))
+				} else {
+					b.set("command", args[0])
+					if len(args) > 1 {
+						b.set("args", args[1:])
+					}
+				}
+			}
+		case "entrypoint":
+			if s, ok := v.(string); ok {
+				b.set("entrypoint", strings.Fields(s))
+			} else {
+				b.set("entrypoint", stringList(v))
+			}
+		case "user", "working_dir", "hostname", "domainname", "stop_signal", "shm_size", "userns_mode":
+			b.set(key, fmt.Sprint(v))
+		case "mem_limit":
+			b.set("memory", fmt.Sprint(v))
+		case "mem_reservation":
+			b.set("memory_reservation", fmt.Sprint(v))
+		case "memswap_limit":
+			b.set("memory_swap", fmt.Sprint(v))
+		case "cpuset":
+			b.set("cpuset_cpus", fmt.Sprint(v))
+		case "tty", "stdin_open", "privileged", "read_only":
+			b.set(key, isTrue(v))
+		case "pids_limit", "cpus", "cpu_shares":
+			b.set(key, hclNumber(v))
+		case "ulimits":
+			b.set("ulimits", ulimits(v))
+		case "dns", "dns_search":
+			b.set(key, stringList(v))
+		case "security_opt":
+			b.set("security_opts", stringList(v))
+		case "extra_hosts":
+			b.set(key, extraHosts(v))
+		case "sysctls":
+			b.set(key, stringMap(v))
+		case "cap_add":
+			capabilities = append(capabilities, hclAttr{"add", stringList(v)})
+		case "cap_drop":
+			capabilities = append(capabilities, hclAttr{"drop", stringList(v)})
+		case "stop_grace_period":
+			d, err := time.ParseDuration(fmt.Sprint(v))
+			if err != nil {
+				c.unsupported(keyPath, "%q is not a duration", v)
+				continue
+			}
+			b.set("stop_timeout", hclExpr(fmt.Sprint(int64(d.Seconds()))))
+		case "ports":
+			b.set("ports", c.ports(keyPath, v))
+		case "volumes":
+			b.set("mounts", c.mounts(keyPath, v))
+		case "environment":
+			b.set("env", c.environment(keyPath, v))
+		case "labels":
+			b.set("labels", stringMap(v))
+		case "logging":
+			logging, _ := v.(map[string]any)
+			if driver, ok := logging["driver"]; ok {
+				b.set("log_driver", fmt.Sprint(driver))
+			}
+			if options, ok := logging["options"]; ok {
+				b.set("log_opts", stringMap(options))
+			}
+		case "depends_on":
+			for _, dep := range dependencies(v) {
+				// Services that are not converted cannot be depended on
+				if svc, _ := services[dep.name].(map[string]any); svc["image"] == nil {
+					continue
+				}
+				if dep.condition != "" && dep.condition != "service_started" {
+					c.unsupported(keyPath, "condition %q of %q is not converted; the container only starts after it is created", dep.condition, dep.name)
+				}
+				dependsOn = append(dependsOn, hclExpr("dockhand_container."+c.label("dockhand_container", dep.name)))
+			}
+		case "networks", "network_mode":
+			c.unsupported(keyPath, "dockhand_container does not attach containers to networks")
+		default:
+			if !strings.HasPrefix(key, "x-") {
+				c.unsupportedKey(path, key, "dockhand_container")
+			}
+		}
+	}
+
+	if len(capabilities) > 0 {
+		b.set("capabilities", capabilities)
+	}
+	if len(dependsOn) > 0 {
+		b.set("depends_on", dependsOn)
+	}
+
+	b.sort(containerAttrOrder)
+}
+
+// unsupportedKey reports a key of a compose object without an equivalent
+// in resourceType.
+func (c *converter) unsupportedKey(path, key, resourceType string) {
+	c.unsupported(path+"."+key, "not supported by %s", resourceType)
+}
+
+// ports converts service ports into dockhand_container port mappings.
+func (c *converter) ports(path string, v any) []string {
+	list, _ := v.([]any)
+
+	ports := make([]string, 0, len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			ports = append(ports, fmt.Sprint(item))
+			continue
+		}
+
+		port := fmt.Sprint(m["target"])
+		if published, ok := m["published"]; ok {
+			port = fmt.Sprint(published) + ":" + port
+			if ip, ok := m["host_ip"]; ok {
+				port = fmt.Sprint(ip) + ":" + port
+			}
+		}
+		if protocol, ok := m["protocol"]; ok && protocol != "tcp" {
+			port += "/" + fmt.Sprint(protocol)
+		}
+		if mode, ok := m["mode"]; ok && mode != "host" && mode != "ingress" {
+			c.unsupported(fmt.Sprintf("%s[%d].mode", path, i), "port mode %q is not supported", mode)
+		}
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+// mounts converts service volumes into dockhand_container mounts, referring
+// to the converted dockhand_volume of named volumes.
+func (c *converter) mounts(path string, v any) []any {
+	list, _ := v.([]any)
+
+	mounts := make([]any, 0, len(list))
+	for i, item := range list {
+		var source, target string
+		var readOnly bool
+
+		if m, ok := item.(map[string]any); ok {
+			if t := fmt.Sprint(m["type"]); t != "volume" && t != "bind" {
+				c.unsupported(fmt.Sprintf("%s[%d]", path, i), "%s mounts are not supported", t)
+				continue
+			}
+			source, target = stringOr(m["source"], ""), fmt.Sprint(m["target"])
+			readOnly = isTrue(m["read_only"])
+		} else {
+			parts := strings.Split(fmt.Sprint(item), ":")
+			switch len(parts) {
+			case 1:
+				target = parts[0]
+			default:
+				source, target = parts[0], parts[1]
+				readOnly = len(parts) > 2 && strings.Contains(parts[2], "ro")
+			}
+		}
+
+		if source == "" {
+			c.unsupported(fmt.Sprintf("%s[%d]", path, i), "anonymous volumes are not supported")
+			continue
+		}
+		mount := target
+		if readOnly {
+			mount += ":ro"
+		}
+
+		if ref, ok := c.volumes[source]; ok {
+			mounts = append(mounts, hclTemplate(ref+":"+hclEscape(mount)))
+			continue
+		}
+
+		if strings.HasPrefix(source, ".") {
+			c.unsupported(fmt.Sprintf("%s[%d]", path, i), "relative bind mount %q is resolved on the Docker host, not next to the compose file", source)
+		}
+		mounts = append(mounts, source+":"+mount)
+	}
+
+	return mounts
+}
+
+// environment converts service environment variables. Variables without a
+// value, which Compose takes from the shell, are reported and left out.
+func (c *converter) environment(path string, v any) map[string]string {
+	vars := toMapping(v)
+
+	env := map[string]string{}
+	for _, key := range sortedKeys(vars) {
+		value := vars[key]
+		if value == nil {
+			c.unsupported(path+"."+key, "variables without a value are taken from the shell by Compose; set a value")
+			continue
+		}
+		env[key] = fmt.Sprint(value)
+	}
+
+	return env
+}
+
+// dependency is a service that another service depends on.
+type dependency struct {
+	name      string
+	condition string
+}
+
+// dependencies returns the services of a depends_on value, sorted by name.
+func dependencies(v any) []dependency {
+	var deps []dependency
+
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			deps = append(deps, dependency{name: fmt.Sprint(item)})
+		}
+	case map[string]any:
+		for _, name := range sortedKeys(v) {
+			opts, _ := v[name].(map[string]any)
+			deps = append(deps, dependency{name: name, condition: stringOr(opts["condition"], "")})
+		}
+	}
+
+	return deps
+}
+
+// ulimits converts service ulimits into dockhand_container ulimits. A single
+// value sets both the soft and the hard limit.
+func ulimits(v any) []any {
+	m, _ := v.(map[string]any)
+
+	limits := make([]any, 0, len(m))
+	for _, name := range sortedKeys(m) {
+		soft, hard := m[name], m[name]
+		if limit, ok := m[name].(map[string]any); ok {
+			soft, hard = limit["soft"], limit["hard"]
+		}
+		limits = append(limits, []hclAttr{
+			{"name", name},
+			{"soft", hclNumber(soft)},
+			{"hard", hclNumber(hard)},
+		})
+	}
+
+	return limits
+}
+
+// extraHosts converts extra_hosts, written as a list or a mapping, into
+// host:ip entries.
+func extraHosts(v any) []string {
+	if m, ok := v.(map[string]any); ok {
+		hosts := make([]string, 0, len(m))
+		for _, host := range sortedKeys(m) {
+			hosts = append(hosts, host+":"+fmt.Sprint(m[host]))
+		}
+		return hosts
+	}
+
+	// Compose also accepts host=ip
+	hosts := stringList(v)
+	for i, h := range hosts {
+		hosts[i] = strings.Replace(h, "=", ":", 1)
+	}
+
+	return hosts
+}
+
+// hasInterpolation reports whether v contains a ${VAR} reference.
+func hasInterpolation(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return strings.Contains(v, "${")
+	case []any:
+		for _, item := range v {
+			if hasInterpolation(item) {
+				return true
+			}
+		}
+	case map[string]any:
+		for _, item := range v {
+			if hasInterpolation(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// stringMap converts a mapping or a list of KEY=VALUE entries into strings.
+// Keys without a value map to an empty string.
+func stringMap(v any) map[string]string {
+	m := map[string]string{}
+	for k, value := range toMapping(v) {
+		if value != nil {
+			m[k] = fmt.Sprint(value)
+		} else {
+			m[k] = ""
+		}
+	}
+
+	return m
+}
+
+// stringList converts a sequence, or a single scalar, into strings.
+func stringList(v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		if v == nil {
+			return nil
+		}
+		return []string{fmt.Sprint(v)}
+	}
+
+	result := make([]string, len(list))
+	for i, item := range list {
+		result[i] = fmt.Sprint(item)
+	}
+
+	return result
+}
+
+// stringOr returns v as a string, or fallback when v is not set.
+func stringOr(v any, fallback string) string {
+	if v == nil {
+		return fallback
+	}
+
+	return fmt.Sprint(v)
+}
+
+// isTrue reports whether v is a YAML true value.
+func isTrue(v any) bool {
+	b, ok := v.(bool)
+	return ok && b
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
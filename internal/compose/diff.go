@@ -0,0 +1,175 @@
+package compose
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Normalize decodes a compose document into a canonical form in which key
+// order, quoting style, comments, anchors and merge keys make no difference.
+// Scalars keep their YAML type, so `80` and `"80"` or `true` and `"true"`
+// differ, while `'nginx'` and `nginx` are equal.
+func Normalize(content []byte) (map[string]any, error) {
+	var doc any
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc == nil {
+		return map[string]any{}, nil
+	}
+
+	m, ok := canonical(doc).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("the compose file must be a mapping")
+	}
+
+	return m, nil
+}
+
+// Equal reports whether two compose documents are semantically equal.
+// Documents that cannot be parsed are compared as plain strings.
+func Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	na, errA := Normalize([]byte(a))
+	nb, errB := Normalize([]byte(b))
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(na, nb)
+}
+
+// canonical converts decoded YAML into maps with string keys. Scalars are
+// kept as decoded.
+func canonical(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = canonical(item)
+		}
+		return m
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[fmt.Sprint(k)] = canonical(item)
+		}
+		return m
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = canonical(item)
+		}
+		return list
+	default:
+		return v
+	}
+}
+
+// Change describes how a service or top-level section differs between two
+// compose documents.
+type Change struct {
+	Kind   string   // "service", or the top-level key such as "networks"
+	Name   string   // Service name; empty for top-level sections
+	Action string   // "added", "removed" or "changed"
+	Keys   []string // Changed keys of a changed service
+}
+
+// String formats the change for display.
+func (c Change) String() string {
+	subject := c.Kind
+	if c.Name != "" {
+		subject = fmt.Sprintf("%s %q", c.Kind, c.Name)
+	}
+
+	if len(c.Keys) > 0 {
+		return fmt.Sprintf("%s %s: %s", subject, c.Action, strings.Join(c.Keys, ", "))
+	}
+
+	return subject + " " + c.Action
+}
+
+// Diff returns the changes between two compose documents: services first,
+// then other top-level sections, each sorted by name.
+func Diff(oldContent, newContent string) ([]Change, error) {
+	a, err := Normalize([]byte(oldContent))
+	if err != nil {
+		return nil, err
+	}
+	b, err := Normalize([]byte(newContent))
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+
+	oldServices, _ := a["services"].(map[string]any)
+	newServices, _ := b["services"].(map[string]any)
+	for _, name := range unionKeys(oldServices, newServices) {
+		before, inOld := oldServices[name]
+		after, inNew := newServices[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: "service", Name: name, Action: "added"})
+		case !inNew:
+			changes = append(changes, Change{Kind: "service", Name: name, Action: "removed"})
+		case !reflect.DeepEqual(before, after):
+			beforeMap, _ := before.(map[string]any)
+			afterMap, _ := after.(map[string]any)
+			var keys []string
+			for _, k := range unionKeys(beforeMap, afterMap) {
+				if !reflect.DeepEqual(beforeMap[k], afterMap[k]) {
+					keys = append(keys, k)
+				}
+			}
+			changes = append(changes, Change{Kind: "service", Name: name, Action: "changed", Keys: keys})
+		}
+	}
+
+	for _, key := range unionKeys(a, b) {
+		if key == "services" {
+			continue
+		}
+
+		before, inOld := a[key]
+		after, inNew := b[key]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: key, Action: "added"})
+		case !inNew:
+			changes = append(changes, Change{Kind: key, Action: "removed"})
+		case !reflect.DeepEqual(before, after):
+			changes = append(changes, Change{Kind: key, Action: "changed"})
+		}
+	}
+
+	return changes, nil
+}
+
+// unionKeys returns the sorted keys present in either map.
+func unionKeys(a, b map[string]any) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,224 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// identifierRegexp matches an HCL identifier.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// numberRegexp matches a decimal number literal.
+var numberRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// labelInvalidRegexp matches characters not allowed in a resource name.
+var labelInvalidRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// hclExpr is an HCL expression written as is, such as a reference.
+type hclExpr string
+
+// hclTemplate is the content of an HCL string template, written in quotes
+// as is. Literal parts must be escaped with hclEscape.
+type hclTemplate string
+
+// hclAttr is an attribute of a generated block or object.
+type hclAttr struct {
+	Name  string
+	Value any
+}
+
+// hclBlock is a generated HCL block, such as a resource.
+type hclBlock struct {
+	Header string
+	Attrs  []hclAttr
+}
+
+// set adds an attribute to the block.
+func (b *hclBlock) set(name string, value any) {
+	b.Attrs = append(b.Attrs, hclAttr{name, value})
+}
+
+// sort orders the attributes of the block as listed in order. Attributes
+// not listed keep their relative order at the end.
+func (b *hclBlock) sort(order []string) {
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+
+	sort.SliceStable(b.Attrs, func(i, j int) bool {
+		ri, ok := rank[b.Attrs[i].Name]
+		if !ok {
+			ri = len(order)
+		}
+		rj, ok := rank[b.Attrs[j].Name]
+		if !ok {
+			rj = len(order)
+		}
+		return ri < rj
+	})
+}
+
+// String formats the block like `terraform fmt`.
+func (b *hclBlock) String() string {
+	return b.Header + " " + hclObject(b.Attrs, 0)
+}
+
+// hclObject formats attributes as the body of a block or an object. The
+// equals signs of consecutive single-line attributes are aligned, and
+// multi-line attributes are set apart by blank lines.
+func hclObject(attrs []hclAttr, indent int) string {
+	if len(attrs) == 0 {
+		return "{}"
+	}
+
+	pad := strings.Repeat("  ", indent+1)
+	values := make([]string, len(attrs))
+	for i, a := range attrs {
+		values[i] = hclValue(a.Value, indent+1)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	for i := 0; i < len(attrs); {
+		if strings.Contains(values[i], "\n") {
+			if i > 0 && !strings.HasSuffix(sb.String(), "\n\n") {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "%s%s = %s\n", pad, hclKey(attrs[i].Name), values[i])
+			if i+1 < len(attrs) {
+				sb.WriteString("\n")
+			}
+			i++
+			continue
+		}
+
+		// Align a run of single-line attributes
+		j, width := i, 0
+		for ; j < len(attrs) && !strings.Contains(values[j], "\n"); j++ {
+			width = max(width, len(hclKey(attrs[j].Name)))
+		}
+		for ; i < j; i++ {
+			fmt.Fprintf(&sb, "%s%-*s = %s\n", pad, width, hclKey(attrs[i].Name), values[i])
+		}
+	}
+	sb.WriteString(strings.Repeat("  ", indent) + "}")
+
+	return sb.String()
+}
+
+// hclValue formats a value as an HCL expression.
+func hclValue(v any, indent int) string {
+	switch v := v.(type) {
+	case hclExpr:
+		return string(v)
+	case string:
+		return hclString(v)
+	case bool, int, int64, float64:
+		return fmt.Sprint(v)
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case hclTemplate:
+		return `"` + string(v) + `"`
+	case []hclExpr:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = string(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclValue(item, indent)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		attrs := make([]hclAttr, len(keys))
+		for i, k := range keys {
+			attrs[i] = hclAttr{k, v[k]}
+		}
+		return hclObject(attrs, indent)
+	case []hclAttr:
+		return hclObject(v, indent)
+	default:
+		return hclString(fmt.Sprint(v))
+	}
+}
+
+// hclNumber returns v as an HCL number, or as a quoted string when it is not
+// a number, such as an unresolved ${VAR} reference.
+func hclNumber(v any) any {
+	s := fmt.Sprint(v)
+	if !numberRegexp.MatchString(s) {
+		return s
+	}
+
+	return hclExpr(s)
+}
+
+// hclKey returns name as an object key, quoted unless it is an identifier.
+func hclKey(name string) string {
+	if identifierRegexp.MatchString(name) {
+		return name
+	}
+
+	return hclString(name)
+}
+
+// hclString quotes s as an HCL string literal. Template sequences are
+// escaped, so the value is used literally.
+func hclString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			sb.WriteRune(r)
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// hclEscape escapes s for use as the literal part of an HCL template.
+func hclEscape(s string) string {
+	quoted := hclString(s)
+	return quoted[1 : len(quoted)-1]
+}
+
+// hclLabel converts a compose name into a resource name.
+func hclLabel(name string) string {
+	label := labelInvalidRegexp.ReplaceAllString(name, "_")
+	if !identifierRegexp.MatchString(label) {
+		label = "_" + label
+	}
+
+	return label
+}
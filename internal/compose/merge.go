@@ -0,0 +1,279 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// replacedKeys are service keys whose sequences replace, rather than extend,
+// the sequence of an earlier file.
+var replacedKeys = keySet("command", "entrypoint")
+
+// mappedKeys are service keys that may be written as a list of KEY=VALUE
+// entries or as a mapping, and are merged by key.
+var mappedKeys = keySet("environment", "labels", "annotations", "sysctls")
+
+// Merge decodes compose documents and merges them in order according to the
+// Compose merge rules: mappings are merged recursively, later scalars replace
+// earlier ones, command and entrypoint are replaced, environment, labels and
+// networks are merged by key, volumes and devices by container path, secrets
+// and configs by source, and other sequences are concatenated without
+// duplicates.
+func Merge(documents ...[]byte) (map[string]any, error) {
+	merged := map[string]any{}
+
+	for i, content := range documents {
+		var doc any
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("compose file %d: %w", i+1, err)
+		}
+		if doc == nil {
+			continue
+		}
+
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("compose file %d: the compose file must be a mapping", i+1)
+		}
+
+		merged = mergeValue(nil, merged, m).(map[string]any)
+	}
+
+	return merged, nil
+}
+
+// ApplyProfiles removes the services whose profiles are not active, and the
+// profiles key of the remaining ones, so that the result runs the selected
+// services without further profile flags. Services without profiles are
+// always kept, and the profile "*" activates every service. depends_on
+// entries naming a removed service are removed as well.
+func ApplyProfiles(doc map[string]any, active []string) {
+	services, _ := doc["services"].(map[string]any)
+
+	enabled := keySet(active...)
+	removed := make(map[string]bool)
+	for name, svc := range services {
+		m, ok := svc.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		profiles, ok := m["profiles"].([]any)
+		if !ok {
+			continue
+		}
+		delete(m, "profiles")
+
+		if enabled["*"] {
+			continue
+		}
+
+		keep := false
+		for _, p := range profiles {
+			if enabled[fmt.Sprint(p)] {
+				keep = true
+			}
+		}
+		if !keep {
+			delete(services, name)
+			removed[name] = true
+		}
+	}
+
+	if len(removed) == 0 {
+		return
+	}
+
+	for _, svc := range services {
+		m, ok := svc.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		switch deps := m["depends_on"].(type) {
+		case []any:
+			kept := make([]any, 0, len(deps))
+			for _, d := range deps {
+				if !removed[fmt.Sprint(d)] {
+					kept = append(kept, d)
+				}
+			}
+			if len(kept) == 0 {
+				delete(m, "depends_on")
+			} else {
+				m["depends_on"] = kept
+			}
+		case map[string]any:
+			for name := range deps {
+				if removed[name] {
+					delete(deps, name)
+				}
+			}
+			if len(deps) == 0 {
+				delete(m, "depends_on")
+			}
+		}
+	}
+}
+
+// Marshal encodes a compose document as YAML.
+func Marshal(doc map[string]any) (string, error) {
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// ServiceNames returns the sorted names of the services of a document.
+func ServiceNames(doc map[string]any) []string {
+	services, _ := doc["services"].(map[string]any)
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// mergeValue merges override into base. path is the location of the values
+// in the document, used to pick the merge rule for sequences.
+func mergeValue(path []string, base, override any) any {
+	if isServiceKey(path, mappedKeys) || isServiceKey(path, keySet("networks")) {
+		if b, o := toMapping(base), toMapping(override); b != nil && o != nil {
+			base, override = b, o
+		}
+	}
+
+	switch o := override.(type) {
+	case map[string]any:
+		b, ok := base.(map[string]any)
+		if !ok {
+			return o
+		}
+
+		result := make(map[string]any, len(b)+len(o))
+		for k, v := range b {
+			result[k] = v
+		}
+		for k, v := range o {
+			if existing, ok := result[k]; ok {
+				result[k] = mergeValue(append(path[:len(path):len(path)], k), existing, v)
+			} else {
+				result[k] = v
+			}
+		}
+		return result
+
+	case []any:
+		b, ok := base.([]any)
+		if !ok || isServiceKey(path, replacedKeys) || pathIs(path, "services", "*", "healthcheck", "test") {
+			return o
+		}
+
+		switch {
+		case isServiceKey(path, keySet("volumes", "devices")):
+			return mergeSequence(b, o, mountTarget)
+		case isServiceKey(path, keySet("secrets", "configs")):
+			return mergeSequence(b, o, referenceSource)
+		default:
+			return mergeSequence(b, o, func(v any) string { return fmt.Sprint(v) })
+		}
+
+	default:
+		return override
+	}
+}
+
+// mergeSequence appends the items of override to base, replacing items of
+// base that have the same key.
+func mergeSequence(base, override []any, key func(any) string) []any {
+	result := append([]any{}, base...)
+
+	index := make(map[string]int, len(result))
+	for i, item := range result {
+		index[key(item)] = i
+	}
+
+	for _, item := range override {
+		if i, ok := index[key(item)]; ok {
+			result[i] = item
+			continue
+		}
+		index[key(item)] = len(result)
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// toMapping converts a list of KEY=VALUE (or bare KEY) entries into a
+// mapping. Mappings are returned unchanged; other values return nil.
+func toMapping(v any) map[string]any {
+	switch v := v.(type) {
+	case map[string]any:
+		return v
+	case []any:
+		m := make(map[string]any, len(v))
+		for _, item := range v {
+			k, value, ok := strings.Cut(fmt.Sprint(item), "=")
+			if ok {
+				m[k] = value
+			} else {
+				m[k] = nil
+			}
+		}
+		return m
+	default:
+		return nil
+	}
+}
+
+// mountTarget returns the container path of a volume or device entry.
+func mountTarget(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		return fmt.Sprint(m["target"])
+	}
+
+	parts := strings.Split(fmt.Sprint(v), ":")
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	return parts[1]
+}
+
+// referenceSource returns the source of a secret or config reference.
+func referenceSource(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		return fmt.Sprint(m["source"])
+	}
+
+	return fmt.Sprint(v)
+}
+
+// isServiceKey reports whether path is a key of a service that is in keys.
+func isServiceKey(path []string, keys map[string]bool) bool {
+	return len(path) == 3 && path[0] == "services" && keys[path[2]]
+}
+
+// pathIs reports whether path matches pattern, where "*" matches any key.
+func pathIs(path []string, pattern ...string) bool {
+	if len(path) != len(pattern) {
+		return false
+	}
+
+	for i := range path {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
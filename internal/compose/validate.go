@@ -0,0 +1,371 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// nameRegexp matches service, network and volume names.
+	nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+	// portRegexp matches the short port syntax:
+	// [[host_ip:]host_port[-range]:]container_port[-range][/protocol]
+	portRegexp = regexp.MustCompile(`^(?:(?:(?:\[[0-9a-fA-F:.]+\]|[0-9.]+):)?(?:\d+(?:-\d+)?)?:)?\d+(?:-\d+)?(?:/(?:tcp|udp|sctp))?$`)
+)
+
+// topLevelKeys are the keys allowed at the root of a compose file.
+var topLevelKeys = keySet("version", "name", "include", "services", "networks", "volumes", "configs", "secrets", "models")
+
+// serviceKeys are the keys allowed in a service definition.
+var serviceKeys = keySet(
+	"annotations", "attach", "blkio_config", "build", "cap_add", "cap_drop", "cgroup", "cgroup_parent",
+	"command", "configs", "container_name", "cpu_count", "cpu_percent", "cpu_period", "cpu_quota",
+	"cpu_rt_period", "cpu_rt_runtime", "cpu_shares", "cpus", "cpuset", "credential_spec", "depends_on",
+	"deploy", "develop", "device_cgroup_rules", "devices", "dns", "dns_opt", "dns_search", "domainname",
+	"entrypoint", "env_file", "environment", "expose", "extends", "external_links", "extra_hosts", "gpus",
+	"group_add", "healthcheck", "hostname", "image", "init", "ipc", "isolation", "label_file", "labels",
+	"links", "logging", "mac_address", "mem_limit", "mem_reservation", "mem_swappiness", "memswap_limit",
+	"models", "network_mode", "networks", "oom_kill_disable", "oom_score_adj", "pid", "pids_limit",
+	"platform", "ports", "post_start", "pre_stop", "privileged", "profiles", "provider", "pull_policy",
+	"pull_refresh_after", "read_only", "restart", "runtime", "scale", "secrets", "security_opt", "shm_size",
+	"stdin_open", "stop_grace_period", "stop_signal", "storage_opt", "sysctls", "tmpfs", "tty", "ulimits",
+	"use_api_socket", "user", "userns_mode", "uts", "volumes", "volumes_from", "working_dir",
+)
+
+// networkKeys are the keys allowed in a top-level network definition.
+var networkKeys = keySet("name", "driver", "driver_opts", "ipam", "external", "internal", "enable_ipv4", "enable_ipv6", "attachable", "labels")
+
+// volumeKeys are the keys allowed in a top-level volume definition.
+var volumeKeys = keySet("name", "driver", "driver_opts", "external", "labels")
+
+// dependsOnConditions are the conditions allowed in long depends_on syntax.
+var dependsOnConditions = keySet("service_started", "service_healthy", "service_completed_successfully")
+
+// Validate parses a compose document and checks it against the Compose
+// specification. It returns the issues found, in document order.
+func Validate(content []byte) []Issue {
+	root, issue := parse(content)
+	if issue != nil {
+		return []Issue{*issue}
+	}
+
+	v := &validator{}
+	v.validate(root)
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Line < v.issues[j].Line
+	})
+
+	return v.issues
+}
+
+// validator collects issues while walking a compose document.
+type validator struct {
+	issues []Issue
+}
+
+func (v *validator) addf(n *yaml.Node, path, format string, args ...any) {
+	v.issues = append(v.issues, *issueAt(n, path, format, args...))
+}
+
+func (v *validator) validate(root *yaml.Node) {
+	v.checkKeys(root, "", topLevelKeys)
+
+	networks := v.definitions(root, "networks", networkKeys)
+	volumes := v.definitions(root, "volumes", volumeKeys)
+
+	services := lookup(root, "services")
+	if isNull(services) {
+		if lookup(root, "include") == nil {
+			v.addf(root, "", "services must be defined")
+		}
+		return
+	}
+	if services.Kind != yaml.MappingNode {
+		v.addf(services, "services", "must be a mapping of service names to definitions")
+		return
+	}
+
+	names := map[string]bool{}
+	for _, p := range pairs(services) {
+		names[p.Key.Value] = true
+	}
+
+	dependencies := map[string][]pair{}
+	for _, p := range pairs(services) {
+		name, path := p.Key.Value, "services."+p.Key.Value
+		if !nameRegexp.MatchString(name) {
+			v.addf(p.Key, path, "invalid service name %q", name)
+		}
+		if p.Value.Kind != yaml.MappingNode {
+			v.addf(p.Value, path, "service definition must be a mapping")
+			continue
+		}
+
+		v.checkKeys(p.Value, path, serviceKeys)
+		if lookup(p.Value, "image") == nil && lookup(p.Value, "build") == nil &&
+			lookup(p.Value, "extends") == nil && lookup(p.Value, "provider") == nil {
+			v.addf(p.Key, path, "service must set image or build")
+		}
+
+		v.checkPorts(lookup(p.Value, "ports"), path+".ports")
+		v.checkServiceNetworks(lookup(p.Value, "networks"), path+".networks", networks)
+		v.checkServiceVolumes(lookup(p.Value, "volumes"), path+".volumes", volumes)
+		dependencies[name] = v.checkDependsOn(lookup(p.Value, "depends_on"), path+".depends_on", names)
+	}
+
+	v.checkCycles(dependencies)
+}
+
+// checkKeys reports keys of mapping n that are not in allowed. Extension
+// keys starting with "x-" are always allowed.
+func (v *validator) checkKeys(n *yaml.Node, path string, allowed map[string]bool) {
+	for _, p := range pairs(n) {
+		key := p.Key.Value
+		if !allowed[key] && !strings.HasPrefix(key, "x-") {
+			v.addf(p.Key, join(path, key), "unsupported key %q", key)
+		}
+	}
+}
+
+// definitions validates a top-level networks or volumes section and returns
+// the names it defines.
+func (v *validator) definitions(root *yaml.Node, section string, allowed map[string]bool) map[string]bool {
+	defined := map[string]bool{}
+
+	n := lookup(root, section)
+	if isNull(n) {
+		return defined
+	}
+	if n.Kind != yaml.MappingNode {
+		v.addf(n, section, "must be a mapping of names to definitions")
+		return defined
+	}
+
+	for _, p := range pairs(n) {
+		path := section + "." + p.Key.Value
+		defined[p.Key.Value] = true
+		if !nameRegexp.MatchString(p.Key.Value) {
+			v.addf(p.Key, path, "invalid name %q", p.Key.Value)
+		}
+		if isNull(p.Value) {
+			continue
+		}
+		if p.Value.Kind != yaml.MappingNode {
+			v.addf(p.Value, path, "definition must be a mapping")
+			continue
+		}
+		v.checkKeys(p.Value, path, allowed)
+	}
+
+	return defined
+}
+
+// checkPorts validates the ports of a service.
+func (v *validator) checkPorts(n *yaml.Node, path string) {
+	if isNull(n) {
+		return
+	}
+	if n.Kind != yaml.SequenceNode {
+		v.addf(n, path, "must be a list")
+		return
+	}
+
+	for i, item := range n.Content {
+		item = resolve(item)
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		switch item.Kind {
+		case yaml.ScalarNode:
+			if !strings.Contains(item.Value, "$") && !portRegexp.MatchString(item.Value) {
+				v.addf(item, itemPath, "invalid port %q, expected [[host_ip:]host_port:]container_port[/protocol]", item.Value)
+			}
+		case yaml.MappingNode:
+			if lookup(item, "target") == nil {
+				v.addf(item, itemPath, "long port syntax requires target")
+			}
+			if p := lookup(item, "protocol"); p != nil && p.Value != "tcp" && p.Value != "udp" && p.Value != "sctp" {
+				v.addf(p, itemPath+".protocol", "invalid protocol %q", p.Value)
+			}
+		default:
+			v.addf(item, itemPath, "must be a string or a mapping")
+		}
+	}
+}
+
+// checkServiceNetworks checks that the networks a service joins are defined.
+func (v *validator) checkServiceNetworks(n *yaml.Node, path string, defined map[string]bool) {
+	if isNull(n) {
+		return
+	}
+
+	check := func(key *yaml.Node) {
+		if key.Value != "default" && !defined[key.Value] {
+			v.addf(key, path, "network %q is not defined in the top-level networks", key.Value)
+		}
+	}
+
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			check(resolve(item))
+		}
+	case yaml.MappingNode:
+		for _, p := range pairs(n) {
+			check(p.Key)
+		}
+	default:
+		v.addf(n, path, "must be a list or a mapping")
+	}
+}
+
+// checkServiceVolumes checks that the named volumes a service mounts are
+// defined. Bind mounts, which start with a path, are not checked.
+func (v *validator) checkServiceVolumes(n *yaml.Node, path string, defined map[string]bool) {
+	if isNull(n) {
+		return
+	}
+	if n.Kind != yaml.SequenceNode {
+		v.addf(n, path, "must be a list")
+		return
+	}
+
+	for i, item := range n.Content {
+		item = resolve(item)
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		var source string
+		switch item.Kind {
+		case yaml.ScalarNode:
+			parts := strings.Split(item.Value, ":")
+			if len(parts) < 2 {
+				continue
+			}
+			source = parts[0]
+		case yaml.MappingNode:
+			if t := lookup(item, "type"); t != nil && t.Value != "volume" {
+				continue
+			}
+			if s := lookup(item, "source"); s != nil {
+				source = s.Value
+			}
+		default:
+			v.addf(item, itemPath, "must be a string or a mapping")
+			continue
+		}
+
+		if source == "" || strings.ContainsAny(source[:1], "/.~$") {
+			continue
+		}
+		if !defined[source] {
+			v.addf(item, itemPath, "volume %q is not defined in the top-level volumes", source)
+		}
+	}
+}
+
+// checkDependsOn validates depends_on and returns the dependencies it names.
+func (v *validator) checkDependsOn(n *yaml.Node, path string, services map[string]bool) []pair {
+	if isNull(n) {
+		return nil
+	}
+
+	var deps []pair
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			deps = append(deps, pair{Key: resolve(item)})
+		}
+	case yaml.MappingNode:
+		for _, p := range pairs(n) {
+			deps = append(deps, p)
+			if c := lookup(p.Value, "condition"); c != nil && !dependsOnConditions[c.Value] {
+				v.addf(c, path+"."+p.Key.Value+".condition", "invalid condition %q", c.Value)
+			}
+		}
+	default:
+		v.addf(n, path, "must be a list or a mapping")
+		return nil
+	}
+
+	for _, d := range deps {
+		if !services[d.Key.Value] {
+			v.addf(d.Key, path, "depends on undefined service %q", d.Key.Value)
+		}
+	}
+
+	return deps
+}
+
+// checkCycles reports dependency cycles between services.
+func (v *validator) checkCycles(dependencies map[string][]pair) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := map[string]int{}
+	var stack []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+
+		for _, d := range dependencies[name] {
+			dep := d.Key.Value
+			switch state[dep] {
+			case visiting:
+				start := 0
+				for stack[start] != dep {
+					start++
+				}
+				cycle := append(append([]string{}, stack[start:]...), dep)
+				v.addf(d.Key, "services."+name+".depends_on", "dependency cycle: %s", strings.Join(cycle, " -> "))
+			case unvisited:
+				if _, ok := dependencies[dep]; ok {
+					visit(dep)
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+}
+
+// keySet returns a set of the given keys.
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+
+	return set
+}
+
+// join appends key to a dotted path.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
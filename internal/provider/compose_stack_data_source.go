@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &ComposeStackDataSource{}
+
+// NewComposeStackDataSource is a helper function to simplify the provider implementation.
+func NewComposeStackDataSource() datasource.DataSource {
+	return &ComposeStackDataSource{}
+}
+
+// ComposeStackDataSource is the data source implementation.
+type ComposeStackDataSource struct {
+	client *client.Client
+}
+
+// ComposeStackDataSourceModel describes the data source data model.
+type ComposeStackDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	EnvironmentID  types.String `tfsdk:"environment_id"`
+	Name           types.String `tfsdk:"name"`
+	Compose        types.String `tfsdk:"compose"`
+	Status         types.String `tfsdk:"status"`
+	DesiredStatus  types.String `tfsdk:"desired_status"`
+	Labels         types.Map    `tfsdk:"labels"`
+	Env            types.Map    `tfsdk:"env"`
+	PullPolicy     types.String `tfsdk:"pull_policy"`
+	AutoSync       types.Bool   `tfsdk:"auto_sync"`
+	GitRepo        types.Object `tfsdk:"git_repo"`
+	DeployedCommit types.String `tfsdk:"deployed_commit"`
+	Services       types.Map    `tfsdk:"services"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// composeGitRepoAttrTypes describes the non-secret parts of a stack's Git
+// repository configuration.
+var composeGitRepoAttrTypes = map[string]attr.Type{
+	"url":    types.StringType,
+	"branch": types.StringType,
+	"path":   types.StringType,
+	"ref":    types.StringType,
+}
+
+// composeServiceAttrTypes describes a service of a compose stack.
+var composeServiceAttrTypes = map[string]attr.Type{
+	"name":   types.StringType,
+	"image":  types.StringType,
+	"status": types.StringType,
+	"count":  types.Int64Type,
+}
+
+// Metadata returns the data source type name.
+func (d *ComposeStackDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compose_stack"
+}
+
+// Schema defines the schema for the data source.
+func (d *ComposeStackDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single compose stack in a Dockhand environment by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The compose stack ID. Exactly one of `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The compose stack name. Exactly one of `id` or `name` must be set.",
+			},
+			"compose": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Docker Compose YAML content.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The current status of the compose stack.",
+			},
+			"desired_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The desired status of the compose stack.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels for the compose stack.",
+			},
+			"env": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Interpolation variables of the compose document. Sensitive, since they often hold secrets.",
+			},
+			"pull_policy": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When service images are pulled on deploy: `always`, `missing` or `never`.",
+			},
+			"auto_sync": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether automatic sync from Git is enabled.",
+			},
+			"deployed_commit": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Git commit currently deployed, for stacks deployed from Git.",
+			},
+			"git_repo": composeGitRepoDataSourceAttribute(),
+			"services": composeServicesDataSourceAttribute(),
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the compose stack was created.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the compose stack was last updated.",
+			},
+		},
+	}
+}
+
+// composeGitRepoDataSourceAttribute returns the computed git_repo attribute of
+// compose stack data sources.
+func composeGitRepoDataSourceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "Git repository configuration. Credentials are never exposed.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Git repository URL.",
+			},
+			"branch": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Git branch deployed from.",
+			},
+			"path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The path within the repository containing the compose file.",
+			},
+			"ref": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The branch, tag or commit deployed, when pinned.",
+			},
+		},
+	}
+}
+
+// composeServicesDataSourceAttribute returns the computed services attribute
+// of compose stack data sources.
+func composeServicesDataSourceAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The services of the stack, keyed by service name.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The service name.",
+				},
+				"image": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The image the service runs.",
+				},
+				"status": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The service status, such as `running` or `exited`.",
+				},
+				"count": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The number of containers of the service.",
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ComposeStackDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ComposeStackDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ComposeStackDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	validateLookupKeys(config.ID, config.Name, "compose stack", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := config.EnvironmentID.ValueString()
+
+	var stack *client.ComposeStack
+	if !config.ID.IsNull() {
+		s, err := d.client.GetComposeStack(environmentID, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading compose stack",
+				"Could not read compose stack: "+err.Error(),
+			)
+			return
+		}
+		stack = s
+	} else {
+		stacks, err := d.client.ListComposeStacks(environmentID, &client.ListFilter{Name: config.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading compose stacks",
+				"Could not read compose stacks: "+err.Error(),
+			)
+			return
+		}
+
+		stack = lookupByName(stacks, config.Name.ValueString(), func(s client.ComposeStack) string { return s.Name }, "compose stack", &resp.Diagnostics)
+		if stack == nil {
+			return
+		}
+	}
+
+	state := ComposeStackDataSourceModel{
+		ID:             types.StringValue(stack.ID),
+		EnvironmentID:  config.EnvironmentID,
+		Name:           types.StringValue(stack.Name),
+		Compose:        types.StringValue(stack.Compose),
+		Status:         types.StringValue(stack.Status),
+		DesiredStatus:  types.StringValue(stack.DesiredStatus),
+		PullPolicy:     types.StringValue(stack.PullPolicy),
+		AutoSync:       types.BoolValue(stack.AutoSync),
+		DeployedCommit: types.StringValue(stack.DeployedCommit),
+		CreatedAt:      types.StringValue(stack.CreatedAt),
+		UpdatedAt:      types.StringValue(stack.UpdatedAt),
+	}
+
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, stack.Labels)
+	resp.Diagnostics.Append(diags...)
+
+	state.Env, diags = types.MapValueFrom(ctx, types.StringType, stack.Env)
+	resp.Diagnostics.Append(diags...)
+
+	state.GitRepo, diags = flattenComposeGitRepo(stack.GitRepo)
+	resp.Diagnostics.Append(diags...)
+
+	state.Services, diags = flattenComposeServices(stack.Services)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read compose stack data source", map[string]any{"id": stack.ID})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenComposeGitRepo converts a stack's Git repository into an object,
+// leaving out its authentication settings.
+func flattenComposeGitRepo(repo *client.GitRepository) (types.Object, diag.Diagnostics) {
+	if repo == nil {
+		return types.ObjectNull(composeGitRepoAttrTypes), nil
+	}
+
+	return types.ObjectValue(composeGitRepoAttrTypes, map[string]attr.Value{
+		"url":    types.StringValue(repo.URL),
+		"branch": types.StringValue(repo.Branch),
+		"path":   types.StringValue(repo.Path),
+		"ref":    types.StringValue(repo.Ref),
+	})
+}
+
+// flattenComposeServices converts a stack's services into a map of objects
+// keyed by service name.
+func flattenComposeServices(services map[string]client.ComposeService) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elems := make(map[string]attr.Value, len(services))
+	for key, svc := range services {
+		name := svc.Name
+		if name == "" {
+			name = key
+		}
+
+		obj, d := types.ObjectValue(composeServiceAttrTypes, map[string]attr.Value{
+			"name":   types.StringValue(name),
+			"image":  types.StringValue(svc.Image),
+			"status": types.StringValue(svc.Status),
+			"count":  types.Int64Value(int64(svc.Count)),
+		})
+		diags.Append(d...)
+		elems[key] = obj
+	}
+
+	m, d := types.MapValue(types.ObjectType{AttrTypes: composeServiceAttrTypes}, elems)
+	diags.Append(d...)
+
+	return m, diags
+}
+
+// notRunningServices returns the services that are not running, sorted by
+// name, as "name (status)".
+func notRunningServices(services map[string]client.ComposeService) []string {
+	var names []string
+	for key, svc := range services {
+		if !strings.HasPrefix(svc.Status, "running") {
+			names = append(names, fmt.Sprintf("%s (%s)", key, svc.Status))
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
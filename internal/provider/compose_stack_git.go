@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// commitRegexp matches a full Git commit SHA.
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// composeGitRepoModel describes the git_repo attribute of a compose stack.
+type composeGitRepoModel struct {
+	URL       types.String `tfsdk:"url"`
+	Branch    types.String `tfsdk:"branch"`
+	Path      types.String `tfsdk:"path"`
+	AuthType  types.String `tfsdk:"auth_type"`
+	AuthToken types.String `tfsdk:"auth_token"`
+	AuthKey   types.String `tfsdk:"auth_key"`
+}
+
+// expandComposeGitRepo converts the git_repo attribute into the API model,
+// deploying ref when it is set. It returns nil when git_repo is not set.
+func expandComposeGitRepo(ctx context.Context, obj types.Object, ref types.String) (*client.GitRepository, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+
+	var m composeGitRepoModel
+	diags := obj.As(ctx, &m, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	repo := &client.GitRepository{
+		URL:    m.URL.ValueString(),
+		Branch: m.Branch.ValueString(),
+		Path:   m.Path.ValueString(),
+		Ref:    ref.ValueString(),
+	}
+
+	if !m.AuthType.IsNull() || !m.AuthToken.IsNull() || !m.AuthKey.IsNull() {
+		repo.Auth = &client.GitAuth{
+			Type:  m.AuthType.ValueString(),
+			Token: m.AuthToken.ValueString(),
+			Key:   m.AuthKey.ValueString(),
+		}
+	}
+
+	return repo, diags
+}
+
+// gitRefDrifted reports whether ref pins a commit other than the deployed
+// one, such as after auto_sync pulled newer commits. Branch and tag names
+// are not compared, since they resolve to a different commit over time.
+func gitRefDrifted(ref types.String, deployedCommit string) bool {
+	if ref.IsNull() || ref.IsUnknown() || deployedCommit == "" {
+		return false
+	}
+
+	return commitRegexp.MatchString(ref.ValueString()) && ref.ValueString() != deployedCommit
+}
+
+// syncGit pulls ref, or the head of the configured branch when ref is empty,
+// and redeploys the stack.
+func (r *ComposeStackResource) syncGit(environmentID, stackID string, ref types.String) (*client.ComposeStack, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	stack, err := r.client.SyncComposeStack(environmentID, stackID, ref.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error syncing compose stack",
+			"Could not sync compose stack from its Git repository: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return stack, diags
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Pull policies of a compose stack, as accepted by `docker compose up --pull`.
+const (
+	pullPolicyAlways  = "always"
+	pullPolicyMissing = "missing"
+	pullPolicyNever   = "never"
+)
+
+// validatePullPolicy checks pull_policy and that pull_triggers can pull.
+func validatePullPolicy(policy types.String, pullTriggers types.Map, diags *diag.Diagnostics) {
+	if policy.IsNull() || policy.IsUnknown() {
+		return
+	}
+
+	switch policy.ValueString() {
+	case pullPolicyAlways, pullPolicyMissing:
+	case pullPolicyNever:
+		if !pullTriggers.IsNull() {
+			diags.AddAttributeError(path.Root("pull_triggers"), "Invalid pull_triggers",
+				"pull_triggers cannot be set when pull_policy is \"never\".")
+		}
+	default:
+		diags.AddAttributeError(path.Root("pull_policy"), "Invalid pull_policy",
+			fmt.Sprintf("pull_policy must be %q, %q or %q, got %q.", pullPolicyAlways, pullPolicyMissing, pullPolicyNever, policy.ValueString()))
+	}
+}
+
+// updatedServices returns the sorted names of the services whose image
+// digest changed in a pull. Services whose image could not be resolved to a
+// digest, such as services that are built, are left out.
+func updatedServices(pulls []client.ComposeImagePull) []string {
+	var names []string
+	for _, p := range pulls {
+		if p.Digest != "" && p.Digest != p.PreviousDigest {
+			names = append(names, p.Service)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// pullImages pulls the images of every service of the stack and recreates
+// the services whose image changed. It returns the recreated services.
+func (r *ComposeStackResource) pullImages(environmentID, stackID string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	pulls, err := r.client.PullComposeStack(environmentID, stackID)
+	if err != nil {
+		diags.AddError(
+			"Error pulling compose stack images",
+			"Could not pull compose stack images: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	services := updatedServices(pulls)
+	if len(services) == 0 {
+		return nil, diags
+	}
+
+	if err := r.client.RecreateComposeServices(environmentID, stackID, services); err != nil {
+		diags.AddError(
+			"Error recreating compose services",
+			fmt.Sprintf("Could not recreate services %v after pulling newer images: %s", services, err.Error()),
+		)
+		return nil, diags
+	}
+
+	return services, diags
+}
@@ -3,7 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -11,10 +16,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+	"github.com/ramorous/terraform-provider-dockhand/internal/compose"
 )
 
 // Ensure the implementation defined in this package is a resource.Resource
 var _ resource.Resource = &ComposeStackResource{}
+var _ resource.ResourceWithValidateConfig = &ComposeStackResource{}
+var _ resource.ResourceWithModifyPlan = &ComposeStackResource{}
 
 // NewComposeStackResource is a helper function to simplify the provider implementation.
 func NewComposeStackResource() resource.Resource {
@@ -28,18 +36,37 @@ type ComposeStackResource struct {
 
 // ComposeStackResourceModel describes the resource data model.
 type ComposeStackResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	EnvironmentID types.String `tfsdk:"environment_id"`
-	Name          types.String `tfsdk:"name"`
-	Compose       types.String `tfsdk:"compose"`
-	Status        types.String `tfsdk:"status"`
-	DesiredStatus types.String `tfsdk:"desired_status"`
-	Labels        types.Map    `tfsdk:"labels"`
-	AutoSync      types.Bool   `tfsdk:"auto_sync"`
-	GitRepo       types.Object `tfsdk:"git_repo"`
-	WebhookToken  types.String `tfsdk:"webhook_token"`
-	CreatedAt     types.String `tfsdk:"created_at"`
-	UpdatedAt     types.String `tfsdk:"updated_at"`
+	ID             types.String `tfsdk:"id"`
+	EnvironmentID  types.String `tfsdk:"environment_id"`
+	Name           types.String `tfsdk:"name"`
+	Compose        ComposeYAML  `tfsdk:"compose"`
+	ComposeFiles   types.List   `tfsdk:"compose_files"`
+	Profiles       types.List   `tfsdk:"profiles"`
+	Status         types.String `tfsdk:"status"`
+	DesiredStatus  types.String `tfsdk:"desired_status"`
+	Labels         types.Map    `tfsdk:"labels"`
+	Env            types.Map    `tfsdk:"env"`
+	EnvSensitive   types.Map    `tfsdk:"env_sensitive"`
+	EnvFile        types.String `tfsdk:"env_file"`
+	PullPolicy     types.String `tfsdk:"pull_policy"`
+	PullTriggers   types.Map    `tfsdk:"pull_triggers"`
+	AutoSync       types.Bool   `tfsdk:"auto_sync"`
+	GitRepo        types.Object `tfsdk:"git_repo"`
+	GitRef         types.String `tfsdk:"git_ref"`
+	DeployedCommit types.String `tfsdk:"deployed_commit"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	TriggerAction  types.String `tfsdk:"trigger_action"`
+	StopTimeout    types.Int64  `tfsdk:"stop_timeout"`
+	RemoveOrphans  types.Bool   `tfsdk:"remove_orphans"`
+	RemoveVolumes  types.Bool   `tfsdk:"remove_volumes"`
+	RemoveImages   types.String `tfsdk:"remove_images"`
+	WebhookToken   types.String `tfsdk:"webhook_token"`
+	Services       types.Map    `tfsdk:"services"`
+	WaitForHealthy types.Bool   `tfsdk:"wait_for_healthy"`
+	WaitTimeout    types.Int64  `tfsdk:"wait_timeout"`
+	WaitServices   types.List   `tfsdk:"wait_services"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
 }
 
 // Metadata returns the resource type name.
@@ -68,8 +95,23 @@ func (r *ComposeStackResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				MarkdownDescription: "The name of the compose stack.",
 			},
 			"compose": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The Docker Compose YAML content.",
+				Optional:   true,
+				CustomType: ComposeYAMLType{},
+				MarkdownDescription: "The Docker Compose YAML content. Exactly one of `compose` or `compose_files` must be set, unless the stack is deployed from `git_repo`. " +
+					"It is checked against the Compose specification at plan time, including service, network and volume references and `depends_on` cycles. " +
+					"Changes that only affect formatting, key order, quoting style or comments are ignored; scalar types are kept, so `80` and `\"80\"` differ. " +
+					"Real changes are summarized per service as a plan warning.",
+			},
+			"compose_files": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Compose documents, such as a base file followed by environment-specific overrides, merged in order by the provider according to the Compose merge rules. " +
+					"The merged document is validated like `compose`. When the deployed document drifts from the merged one, the drift is shown on `compose` and the next apply redeploys the files.",
+			},
+			"profiles": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Compose profiles to activate. Services with `profiles` run only when one of them is listed here; services without profiles always run. `*` activates every profile. `depends_on` entries naming a service that does not run are dropped.",
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -84,6 +126,33 @@ func (r *ComposeStackResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				ElementType:         types.StringType,
 				MarkdownDescription: "Labels for the compose stack.",
 			},
+			"env": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Variables for `${VAR}` interpolation in the compose document. Changing them redeploys the stack.",
+			},
+			"env_sensitive": schema.MapAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Interpolation variables whose values are hidden from plan output, such as passwords. Merged with `env`; a key may not be set in both.",
+			},
+			"env_file": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Content of a `.env` file with `KEY=VALUE` lines, e.g. `file(\"${path.module}/.env\")`. Variables in `env` and `env_sensitive` take precedence.",
+			},
+			"pull_policy": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "When service images are pulled on deploy: `always`, `missing` (only images not present locally) or `never`. " +
+					"Defaults to the Dockhand setting.",
+			},
+			"pull_triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Map of arbitrary values. When they change, the images of all services are pulled and only the services whose image digest changed are recreated, " +
+					"e.g. to pick up new `:latest` images without replacing the stack.",
+			},
 			"auto_sync": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Enable automatic sync from Git repository.",
@@ -120,11 +189,76 @@ func (r *ComposeStackResource) Schema(ctx context.Context, _ resource.SchemaRequ
 					},
 				},
 			},
+			"git_ref": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The branch, tag or commit of `git_repo` to deploy. Changing it syncs the stack from the repository and redeploys it. " +
+					"When it is a full commit SHA and a different commit is deployed, for example by `auto_sync`, the drift shows in the plan. " +
+					"Branches and tags are not tracked: new commits on them are not detected, so use `auto_sync` or change `git_ref` to deploy them.",
+			},
+			"deployed_commit": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Git commit currently deployed, for stacks with `git_repo`.",
+			},
+			"triggers":       triggersAttribute("stack"),
+			"trigger_action": triggerActionAttribute(),
+			"wait_for_healthy": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Wait after create and update until the services are running and, if they have a health check, healthy. Defaults to `false`.",
+			},
+			"wait_timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Seconds to wait for the services when `wait_for_healthy` is set. Defaults to %d.", defaultComposeWaitTimeout),
+			},
+			"wait_services": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The services to wait for. Defaults to every service of the stack.",
+			},
+			"stop_timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Seconds to wait for services to stop when the stack is destroyed, before killing them.",
+			},
+			"remove_orphans": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove containers of services no longer in the compose file when the stack is destroyed. Defaults to `false`.",
+			},
+			"remove_volumes": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the named volumes declared in the compose file, and anonymous volumes, when the stack is destroyed. Defaults to `false`.",
+			},
+			"remove_images": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove service images when the stack is destroyed: `all` for every image, or `local` for images without a custom tag. By default images are kept.",
+			},
 			"webhook_token": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
 				MarkdownDescription: "The webhook token for automatic deployments.",
 			},
+			"services": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The services of the stack, keyed by service name. A warning is shown after apply for any service that is not running.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The service name.",
+						},
+						"image": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The image the service runs.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The service status, such as `running` or `exited`.",
+						},
+						"count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of containers of the service.",
+						},
+					},
+				},
+			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "When the compose stack was created.",
@@ -157,6 +291,122 @@ func (r *ComposeStackResource) Configure(_ context.Context, req resource.Configu
 	r.client = client
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *ComposeStackResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ComposeStackResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Compose.IsUnknown() && !config.ComposeFiles.IsUnknown() {
+		switch {
+		case !config.Compose.IsNull() && !config.ComposeFiles.IsNull():
+			resp.Diagnostics.AddAttributeError(path.Root("compose"), "Invalid compose configuration",
+				"Only one of compose or compose_files can be set.")
+		case config.Compose.IsNull() && config.ComposeFiles.IsNull() && config.GitRepo.IsNull():
+			resp.Diagnostics.AddAttributeError(path.Root("compose"), "Invalid compose configuration",
+				"Exactly one of compose or compose_files must be set, unless git_repo is set.")
+		}
+	}
+
+	if !config.GitRef.IsNull() && config.GitRepo.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("git_ref"), "Invalid git_ref",
+			"git_ref requires git_repo to be set.")
+	}
+
+	var services []string
+	if config.composeKnown() && !config.fromGit() && !resp.Diagnostics.HasError() {
+		services = config.validateCompose(ctx, &resp.Diagnostics)
+	}
+
+	validateEnvMaps(config.Env, config.EnvSensitive, &resp.Diagnostics)
+
+	if !config.EnvFile.IsNull() && !config.EnvFile.IsUnknown() {
+		if _, err := parseEnvFile(config.EnvFile.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("env_file"), "Invalid env file", err.Error())
+		}
+	}
+
+	validateTriggerAction(config.TriggerAction, &resp.Diagnostics)
+	validatePullPolicy(config.PullPolicy, config.PullTriggers, &resp.Diagnostics)
+
+	if v := config.StopTimeout; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("stop_timeout"), "Invalid stop timeout",
+			fmt.Sprintf("stop_timeout must be zero or a positive number of seconds, got %d.", v.ValueInt64()))
+	}
+
+	if v := config.WaitTimeout; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("wait_timeout"), "Invalid wait timeout",
+			fmt.Sprintf("wait_timeout must be a positive number of seconds, got %d.", v.ValueInt64()))
+	}
+
+	if config.WaitForHealthy.ValueBool() && config.DesiredStatus.ValueString() == "stopped" {
+		resp.Diagnostics.AddAttributeError(path.Root("wait_for_healthy"), "Invalid wait_for_healthy",
+			"wait_for_healthy cannot be set when desired_status is \"stopped\".")
+	}
+
+	if names := knownStrings(ctx, config.WaitServices, &resp.Diagnostics); len(names) > 0 && services != nil {
+		defined := map[string]bool{}
+		for _, name := range services {
+			defined[name] = true
+		}
+		for i, name := range names {
+			if !defined[name] {
+				resp.Diagnostics.AddAttributeError(path.Root("wait_services").AtListIndex(i), "Unknown service",
+					fmt.Sprintf("Service %q is not defined in the compose document, or its profile is not active.", name))
+			}
+		}
+	}
+
+	if v := config.RemoveImages; !v.IsNull() && !v.IsUnknown() && v.ValueString() != "all" && v.ValueString() != "local" {
+		resp.Diagnostics.AddAttributeError(path.Root("remove_images"), "Invalid remove_images",
+			fmt.Sprintf("remove_images must be \"all\" or \"local\", got %q.", v.ValueString()))
+	}
+}
+
+// ModifyPlan summarizes compose changes per service, since the plain text
+// diff of a large compose document is hard to read.
+func (r *ComposeStackResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan ComposeStackResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.composeKnown() {
+		return
+	}
+
+	before, diags := state.effectiveCompose(ctx)
+	resp.Diagnostics.Append(diags...)
+	after, diags := plan.effectiveCompose(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || before == after {
+		return
+	}
+
+	changes, err := compose.Diff(before, after)
+	if err != nil || len(changes) == 0 {
+		return
+	}
+
+	summary := make([]string, 0, len(changes))
+	for _, c := range changes {
+		summary = append(summary, "  - "+c.String())
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("compose"),
+		"Compose stack changes",
+		fmt.Sprintf("The compose document of stack %q changes:\n%s", plan.Name.ValueString(), strings.Join(summary, "\n")),
+	)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ComposeStackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ComposeStackResourceModel
@@ -167,11 +417,18 @@ func (r *ComposeStackResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	composeContent, diags := plan.effectiveCompose(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create the compose stack
 	stackReq := &client.ComposeStack{
-		Name:     plan.Name.ValueString(),
-		Compose:  plan.Compose.ValueString(),
-		AutoSync: plan.AutoSync.ValueBool(),
+		Name:       plan.Name.ValueString(),
+		Compose:    composeContent,
+		PullPolicy: plan.PullPolicy.ValueString(),
+		AutoSync:   plan.AutoSync.ValueBool(),
 	}
 
 	// Convert labels
@@ -181,6 +438,19 @@ func (r *ComposeStackResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	stackReq.Labels = labels
 
+	stackReq.Env, diags = mergeEnv(ctx, plan.Env, plan.EnvSensitive)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stackReq.EnvFile = plan.EnvFile.ValueString()
+
+	stackReq.GitRepo, diags = expandComposeGitRepo(ctx, plan.GitRepo, plan.GitRef)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createdStack, err := r.client.CreateComposeStack(plan.EnvironmentID.ValueString(), stackReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -190,12 +460,48 @@ func (r *ComposeStackResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	// Deploy the requested Git reference
+	if !plan.GitRef.IsNull() {
+		stack, diags := r.syncGit(plan.EnvironmentID.ValueString(), createdStack.ID, plan.GitRef)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createdStack.Status, createdStack.Services, createdStack.DeployedCommit = stack.Status, stack.Services, stack.DeployedCommit
+	}
+
+	// Wait for the services to become healthy
+	var waitErr error
+	if plan.WaitForHealthy.ValueBool() {
+		var stack *client.ComposeStack
+		stack, waitErr = r.waitForHealthy(ctx, plan.EnvironmentID.ValueString(), createdStack.ID, plan)
+		if stack != nil {
+			createdStack.Status, createdStack.Services = stack.Status, stack.Services
+		}
+	}
+
 	// Set state
 	plan.ID = types.StringValue(createdStack.ID)
 	plan.Status = types.StringValue(createdStack.Status)
 	plan.CreatedAt = types.StringValue(createdStack.CreatedAt)
 	plan.UpdatedAt = types.StringValue(createdStack.UpdatedAt)
 	plan.WebhookToken = types.StringValue(createdStack.WebhookToken)
+	plan.DeployedCommit = types.StringValue(createdStack.DeployedCommit)
+
+	plan.Services, diags = flattenComposeServices(createdStack.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError(
+			"Compose services not healthy",
+			fmt.Sprintf("Compose stack %q was created, but its services did not become healthy: %s", createdStack.Name, waitErr.Error()),
+		)
+	} else {
+		warnNotRunning(createdStack, plan.DesiredStatus, &resp.Diagnostics)
+	}
 
 	tflog.Trace(ctx, "Created compose stack", map[string]any{"id": createdStack.ID})
 
@@ -225,9 +531,34 @@ func (r *ComposeStackResource) Read(ctx context.Context, req resource.ReadReques
 
 	// Update state
 	state.Name = types.StringValue(stack.Name)
-	state.Compose = types.StringValue(stack.Compose)
+	// Only record the compose document when it drifted from the configured
+	// one. Stacks deployed from Git take it from the repository. Drift from
+	// compose_files is recorded on compose, keeping the files as written, so
+	// the next plan shows the deployed document and restores the files.
+	if !state.fromGit() {
+		if !state.ComposeFiles.IsNull() {
+			state.Compose = NewComposeYAMLNull()
+		}
+
+		expected, diags := state.effectiveCompose(ctx)
+		resp.Diagnostics.Append(diags...)
+		if !compose.Equal(expected, stack.Compose) {
+			state.Compose = NewComposeYAMLValue(stack.Compose)
+		}
+	}
 	state.Status = types.StringValue(stack.Status)
 	state.UpdatedAt = types.StringValue(stack.UpdatedAt)
+	state.DeployedCommit = types.StringValue(stack.DeployedCommit)
+	if gitRefDrifted(state.GitRef, stack.DeployedCommit) {
+		state.GitRef = types.StringValue(stack.DeployedCommit)
+	}
+
+	state.Services, diags = flattenComposeServices(stack.Services)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(refreshEnvMaps(ctx, stack.Env, &state.Env, &state.EnvSensitive)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Trace(ctx, "Read compose stack", map[string]any{"id": stack.ID})
 
@@ -237,34 +568,129 @@ func (r *ComposeStackResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ComposeStackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ComposeStackResourceModel
+	var plan, state ComposeStackResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Update the compose stack
-	stackReq := &client.ComposeStack{
-		ID:       plan.ID.ValueString(),
-		Name:     plan.Name.ValueString(),
-		Compose:  plan.Compose.ValueString(),
-		AutoSync: plan.AutoSync.ValueBool(),
+	stackReq, diags := plan.updateRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.updateRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	updatedStack, err := r.client.UpdateComposeStack(plan.EnvironmentID.ValueString(), plan.ID.ValueString(), stackReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating compose stack",
-			"Could not update compose stack: "+err.Error(),
-		)
-		return
+	// Update the compose stack only when a setting sent to Dockhand changed,
+	// since an update redeploys it. Destroy, wait and trigger options are
+	// only recorded, and pull_triggers only pulls images below.
+	var updatedStack *client.ComposeStack
+	var err error
+	if reflect.DeepEqual(stackReq, current) {
+		updatedStack, err = r.client.GetComposeStack(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading compose stack",
+				"Could not read compose stack: "+err.Error(),
+			)
+			return
+		}
+	} else {
+		updatedStack, err = r.client.UpdateComposeStack(plan.EnvironmentID.ValueString(), plan.ID.ValueString(), stackReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating compose stack",
+				"Could not update compose stack: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Sync from Git when the repository or the reference changed
+	if !plan.GitRepo.IsNull() && (!plan.GitRef.Equal(state.GitRef) || !plan.GitRepo.Equal(state.GitRepo)) {
+		updatedStack, diags = r.syncGit(plan.EnvironmentID.ValueString(), plan.ID.ValueString(), plan.GitRef)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Pull newer images when the pull triggers changed
+	if triggersChanged(state.PullTriggers, plan.PullTriggers, types.StringNull()) {
+		services, diags := r.pullImages(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		tflog.Info(ctx, "Pulled compose stack images", map[string]any{"id": plan.ID.ValueString(), "recreated": services})
+
+		if len(services) > 0 {
+			updatedStack, err = r.client.GetComposeStack(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading compose stack",
+					"Could not read compose stack after recreating services: "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
+	// Restart the stack when its triggers changed
+
+	if triggersChanged(state.Triggers, plan.Triggers, plan.TriggerAction) {
+		if err := r.client.RestartComposeStack(plan.EnvironmentID.ValueString(), plan.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error restarting compose stack",
+				"Could not restart compose stack after triggers changed: "+err.Error(),
+			)
+			return
+		}
+
+		updatedStack, err = r.client.GetComposeStack(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading compose stack",
+				"Could not read compose stack after restart: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Wait for the services to become healthy
+	var waitErr error
+	if plan.WaitForHealthy.ValueBool() {
+		var stack *client.ComposeStack
+		stack, waitErr = r.waitForHealthy(ctx, plan.EnvironmentID.ValueString(), plan.ID.ValueString(), plan)
+		if stack != nil {
+			updatedStack = stack
+		}
 	}
 
 	// Update state
 	plan.Status = types.StringValue(updatedStack.Status)
 	plan.UpdatedAt = types.StringValue(updatedStack.UpdatedAt)
+	plan.DeployedCommit = types.StringValue(updatedStack.DeployedCommit)
+
+	plan.Services, diags = flattenComposeServices(updatedStack.Services)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError(
+			"Compose services not healthy",
+			fmt.Sprintf("Compose stack %q was updated, but its services did not become healthy: %s", updatedStack.Name, waitErr.Error()),
+		)
+	} else {
+		warnNotRunning(updatedStack, plan.DesiredStatus, &resp.Diagnostics)
+	}
 
 	tflog.Trace(ctx, "Updated compose stack", map[string]any{"id": updatedStack.ID})
 
@@ -282,8 +708,28 @@ func (r *ComposeStackResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	environmentID, stackID := state.EnvironmentID.ValueString(), state.ID.ValueString()
+
+	// Stop the services gracefully first. Deleting the stack stops anything
+	// still running, so a failure here is not fatal.
+	if state.Status.ValueString() != "stopped" {
+		opts := &client.StopOptions{}
+		if !state.StopTimeout.IsNull() {
+			timeout := int(state.StopTimeout.ValueInt64())
+			opts.Timeout = &timeout
+		}
+
+		if err := r.client.StopComposeStack(environmentID, stackID, opts); err != nil {
+			tflog.Warn(ctx, "Could not stop compose stack before deleting it", map[string]any{"id": stackID, "error": err.Error()})
+		}
+	}
+
 	// Delete the compose stack
-	err := r.client.DeleteComposeStack(state.EnvironmentID.ValueString(), state.ID.ValueString())
+	err := r.client.DeleteComposeStack(environmentID, stackID, &client.DeleteComposeStackOptions{
+		RemoveOrphans: state.RemoveOrphans.ValueBool(),
+		RemoveVolumes: state.RemoveVolumes.ValueBool(),
+		RemoveImages:  state.RemoveImages.ValueString(),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting compose stack",
@@ -294,3 +740,192 @@ func (r *ComposeStackResource) Delete(ctx context.Context, req resource.DeleteRe
 
 	tflog.Trace(ctx, "Deleted compose stack", map[string]any{"id": state.ID.ValueString()})
 }
+
+// updateRequest returns the compose stack settings sent to Dockhand on update.
+func (m ComposeStackResourceModel) updateRequest(ctx context.Context) (*client.ComposeStack, diag.Diagnostics) {
+	composeContent, diags := m.effectiveCompose(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	stack := &client.ComposeStack{
+		ID:         m.ID.ValueString(),
+		Name:       m.Name.ValueString(),
+		Compose:    composeContent,
+		PullPolicy: m.PullPolicy.ValueString(),
+		AutoSync:   m.AutoSync.ValueBool(),
+		EnvFile:    m.EnvFile.ValueString(),
+	}
+
+	var d diag.Diagnostics
+	stack.Env, d = mergeEnv(ctx, m.Env, m.EnvSensitive)
+	diags.Append(d...)
+
+	stack.GitRepo, d = expandComposeGitRepo(ctx, m.GitRepo, m.GitRef)
+	diags.Append(d...)
+
+	return stack, diags
+}
+
+// waitForHealthy waits for the services selected by plan to become healthy.
+func (r *ComposeStackResource) waitForHealthy(ctx context.Context, environmentID, stackID string, plan ComposeStackResourceModel) (*client.ComposeStack, error) {
+	timeout := int64(defaultComposeWaitTimeout)
+	if !plan.WaitTimeout.IsNull() {
+		timeout = plan.WaitTimeout.ValueInt64()
+	}
+
+	var names []string
+	plan.WaitServices.ElementsAs(ctx, &names, false)
+
+	tflog.Debug(ctx, "Waiting for compose services", map[string]any{"id": stackID, "services": names, "timeout": timeout})
+
+	return waitForServices(ctx, func() (*client.ComposeStack, error) {
+		return r.client.GetComposeStack(environmentID, stackID)
+	}, names, time.Duration(timeout)*time.Second, composeWaitInterval)
+}
+
+// warnNotRunning adds a warning listing the services of stack that are not
+// running, unless the stack is meant to be stopped.
+func warnNotRunning(stack *client.ComposeStack, desiredStatus types.String, diags *diag.Diagnostics) {
+	if desiredStatus.ValueString() == "stopped" {
+		return
+	}
+
+	if services := notRunningServices(stack.Services); len(services) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("services"),
+			"Compose services not running",
+			fmt.Sprintf("The following services of stack %q are not running after apply: %s.", stack.Name, strings.Join(services, ", ")),
+		)
+	}
+}
+
+// composeKnown reports whether the compose document of m is known.
+func (m ComposeStackResourceModel) composeKnown() bool {
+	if m.Compose.IsUnknown() || m.ComposeFiles.IsUnknown() || m.Profiles.IsUnknown() {
+		return false
+	}
+
+	for _, list := range []types.List{m.ComposeFiles, m.Profiles} {
+		for _, e := range list.Elements() {
+			if e.IsUnknown() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// fromGit reports whether the compose document of m comes from its Git
+// repository rather than from compose or compose_files.
+func (m ComposeStackResourceModel) fromGit() bool {
+	return !m.GitRepo.IsNull() && m.Compose.IsNull() && m.ComposeFiles.IsNull()
+}
+
+// effectiveCompose returns the compose document deployed for m: compose, or
+// compose_files merged in order, with the selected profiles applied. compose
+// is returned unchanged when no profiles are set, or when it records drift
+// of compose_files.
+func (m ComposeStackResourceModel) effectiveCompose(ctx context.Context) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// compose is only set along with compose_files in state, where it records
+	// the document found deployed instead of the merged files.
+	if (m.ComposeFiles.IsNull() && m.Profiles.IsNull()) || (!m.ComposeFiles.IsNull() && !m.Compose.IsNull()) {
+		return m.Compose.ValueString(), diags
+	}
+
+	documents := []string{m.Compose.ValueString()}
+	if !m.ComposeFiles.IsNull() {
+		diags.Append(m.ComposeFiles.ElementsAs(ctx, &documents, false)...)
+	}
+
+	var profiles []string
+	diags.Append(m.Profiles.ElementsAs(ctx, &profiles, false)...)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	contents := make([][]byte, 0, len(documents))
+	for _, d := range documents {
+		contents = append(contents, []byte(d))
+	}
+
+	doc, err := compose.Merge(contents...)
+	if err != nil {
+		diags.AddAttributeError(path.Root("compose_files"), "Invalid compose files", "Could not merge compose files: "+err.Error())
+		return "", diags
+	}
+
+	if !m.Profiles.IsNull() {
+		compose.ApplyProfiles(doc, profiles)
+	}
+
+	content, err := compose.Marshal(doc)
+	if err != nil {
+		diags.AddAttributeError(path.Root("compose_files"), "Invalid compose files", "Could not encode the merged compose document: "+err.Error())
+		return "", diags
+	}
+
+	return content, diags
+}
+
+// validateCompose checks the compose document of m against the Compose
+// specification and returns its service names. compose is checked as
+// written, so issues carry line numbers; compose_files are checked for
+// syntax one by one, then merged and checked as a whole.
+func (m ComposeStackResourceModel) validateCompose(ctx context.Context, diags *diag.Diagnostics) []string {
+	var content string
+
+	if m.ComposeFiles.IsNull() {
+		content = m.Compose.ValueString()
+		issues := compose.Validate([]byte(content))
+		for _, issue := range issues {
+			diags.AddAttributeError(path.Root("compose"), "Invalid compose file", issue.Error())
+		}
+		if len(issues) > 0 {
+			return nil
+		}
+	} else {
+		var files []string
+		diags.Append(m.ComposeFiles.ElementsAs(ctx, &files, false)...)
+		for i, f := range files {
+			if issue := compose.CheckSyntax([]byte(f)); issue != nil {
+				diags.AddAttributeError(path.Root("compose_files").AtListIndex(i), "Invalid compose file", issue.Error())
+			}
+		}
+		if diags.HasError() {
+			return nil
+		}
+
+		var d diag.Diagnostics
+		content, d = m.effectiveCompose(ctx)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil
+		}
+
+		issues := compose.Validate([]byte(content))
+		for _, issue := range issues {
+			// Line numbers refer to the merged document, not to any one file
+			issue.Line = 0
+			diags.AddAttributeError(path.Root("compose_files"), "Invalid merged compose document", issue.Error())
+		}
+		if len(issues) > 0 {
+			return nil
+		}
+	}
+
+	doc, err := compose.Merge([]byte(content))
+	if err != nil {
+		return nil
+	}
+	if m.ComposeFiles.IsNull() && !m.Profiles.IsNull() {
+		var profiles []string
+		diags.Append(m.Profiles.ElementsAs(ctx, &profiles, false)...)
+		compose.ApplyProfiles(doc, profiles)
+	}
+
+	return compose.ServiceNames(doc)
+}
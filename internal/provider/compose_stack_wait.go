@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+const (
+	// defaultComposeWaitTimeout is the number of seconds to wait for services
+	// when wait_timeout is not set.
+	defaultComposeWaitTimeout = 300
+
+	// composeWaitInterval is the delay between two status checks.
+	composeWaitInterval = 5 * time.Second
+)
+
+// waitForServices polls get until every service in names, or every service
+// of the stack when names is empty, is running and not unhealthy. On timeout
+// the error lists the reason each service is not ready.
+func waitForServices(ctx context.Context, get func() (*client.ComposeStack, error), names []string, timeout, interval time.Duration) (*client.ComposeStack, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		stack, err := get()
+		if err != nil {
+			return nil, err
+		}
+
+		failures := serviceFailures(stack.Services, names)
+		if len(failures) == 0 {
+			return stack, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return stack, fmt.Errorf("services not healthy after %s:\n  - %s", timeout, strings.Join(failures, "\n  - "))
+		}
+
+		select {
+		case <-ctx.Done():
+			return stack, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// serviceFailures returns, sorted by service name, why each selected service
+// is not ready. It returns nil when all of them are ready.
+func serviceFailures(services map[string]client.ComposeService, names []string) []string {
+	if len(names) == 0 {
+		for name := range services {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return []string{"the stack has no services yet"}
+		}
+	}
+
+	var failures []string
+	for _, name := range names {
+		svc, ok := services[name]
+		switch {
+		case !ok:
+			failures = append(failures, fmt.Sprintf("%s: not found in the stack", name))
+		case !serviceReady(svc.Status):
+			failures = append(failures, fmt.Sprintf("%s: %s", name, svc.Status))
+		}
+	}
+	sort.Strings(failures)
+
+	return failures
+}
+
+// serviceReady reports whether a service status is running and, when the
+// service has a health check, healthy.
+func serviceReady(status string) bool {
+	status = strings.ToLower(status)
+	if strings.Contains(status, "unhealthy") || strings.Contains(status, "starting") {
+		return false
+	}
+
+	return strings.HasPrefix(status, "running") || status == "healthy"
+}
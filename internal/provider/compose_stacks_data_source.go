@@ -2,34 +2,277 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
 
+// Ensure the implementation defined in this package is a datasource.DataSource
 var _ datasource.DataSource = &ComposeStacksDataSource{}
 
+// NewComposeStacksDataSource is a helper function to simplify the provider implementation.
 func NewComposeStacksDataSource() datasource.DataSource {
 	return &ComposeStacksDataSource{}
 }
 
+// ComposeStacksDataSource is the data source implementation.
 type ComposeStacksDataSource struct {
 	client *client.Client
 }
 
+// ComposeStacksDataSourceModel describes the data source data model.
+type ComposeStacksDataSourceModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Filter        types.Object `tfsdk:"filter"`
+	Stacks        types.List   `tfsdk:"stacks"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// ComposeStacksFilterModel describes the filter attribute of the data source.
+type ComposeStacksFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+	Status    types.String `tfsdk:"status"`
+}
+
+// ComposeStackData describes a compose stack in the data source
+type ComposeStackData struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Compose        types.String `tfsdk:"compose"`
+	Status         types.String `tfsdk:"status"`
+	DesiredStatus  types.String `tfsdk:"desired_status"`
+	Labels         types.Map    `tfsdk:"labels"`
+	PullPolicy     types.String `tfsdk:"pull_policy"`
+	AutoSync       types.Bool   `tfsdk:"auto_sync"`
+	GitRepo        types.Object `tfsdk:"git_repo"`
+	DeployedCommit types.String `tfsdk:"deployed_commit"`
+	Services       types.Map    `tfsdk:"services"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// composeStackDataAttrTypes describes a compose stack in the data source.
+var composeStackDataAttrTypes = map[string]attr.Type{
+	"id":              types.StringType,
+	"name":            types.StringType,
+	"compose":         types.StringType,
+	"status":          types.StringType,
+	"desired_status":  types.StringType,
+	"labels":          types.MapType{ElemType: types.StringType},
+	"pull_policy":     types.StringType,
+	"auto_sync":       types.BoolType,
+	"git_repo":        types.ObjectType{AttrTypes: composeGitRepoAttrTypes},
+	"deployed_commit": types.StringType,
+	"services":        types.MapType{ElemType: types.ObjectType{AttrTypes: composeServiceAttrTypes}},
+	"created_at":      types.StringType,
+	"updated_at":      types.StringType,
+}
+
+// Metadata returns the data source type name.
 func (d *ComposeStacksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_compose_stacks"
 }
 
+// Schema defines the schema for the data source.
 func (d *ComposeStacksDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Fetches a list of compose stacks in a Dockhand environment.",
+		MarkdownDescription: "Fetches the compose stacks of a Dockhand environment with their full definitions, e.g. to compare stacks across environments.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The data source ID.",
+			},
+			"filter": listFilterAttribute(map[string]schema.Attribute{
+				"status": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include stacks with this status, e.g. `running`.",
+				},
+			}),
+			"stacks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of compose stacks.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The stack ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The stack name.",
+						},
+						"compose": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The Docker Compose YAML content.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The current status of the stack.",
+						},
+						"desired_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The desired status of the stack.",
+						},
+						"labels": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The labels.",
+						},
+						"pull_policy": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When service images are pulled on deploy: `always`, `missing` or `never`.",
+						},
+						"auto_sync": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the stack is synced automatically from Git.",
+						},
+						"git_repo": composeGitRepoDataSourceAttribute(),
+						"deployed_commit": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The Git commit currently deployed, for stacks deployed from Git.",
+						},
+						"services": composeServicesDataSourceAttribute(),
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the stack was created.",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the stack was last updated.",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
+// Configure adds the provider configured client to the data source.
 func (d *ComposeStacksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
 }
 
+// Read refreshes the Terraform state with the latest data.
 func (d *ComposeStacksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ComposeStacksDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filterModel ComposeStacksFilterModel
+	if !config.Filter.IsNull() {
+		resp.Diagnostics.Append(config.Filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{})...)
+	}
+
+	filter := newListFilter(ctx, filterModel.NameRegex, filterModel.Labels, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get compose stacks
+	stacks, err := d.client.ListComposeStacks(config.EnvironmentID.ValueString(), &client.ListFilter{
+		Labels: filter.labels,
+		State:  filterModel.Status.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading compose stacks",
+			"Could not read compose stacks: "+err.Error(),
+		)
+		return
+	}
+
+	// Convert to Terraform types
+	stacksList := []ComposeStackData{}
+	for _, s := range stacks {
+		if !filter.matchName(s.Name) || !filter.matchLabels(s.Labels) {
+			continue
+		}
+		if status := filterModel.Status.ValueString(); status != "" && s.Status != status {
+			continue
+		}
+
+		// The list may only return summaries; fetch the full definition
+		if s.Compose == "" {
+			full, err := d.client.GetComposeStack(config.EnvironmentID.ValueString(), s.ID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading compose stack",
+					fmt.Sprintf("Could not read compose stack %q: %s", s.Name, err.Error()),
+				)
+				return
+			}
+			s = *full
+		}
+
+		item := ComposeStackData{
+			ID:             types.StringValue(s.ID),
+			Name:           types.StringValue(s.Name),
+			Compose:        types.StringValue(s.Compose),
+			Status:         types.StringValue(s.Status),
+			DesiredStatus:  types.StringValue(s.DesiredStatus),
+			PullPolicy:     types.StringValue(s.PullPolicy),
+			AutoSync:       types.BoolValue(s.AutoSync),
+			DeployedCommit: types.StringValue(s.DeployedCommit),
+			CreatedAt:      types.StringValue(s.CreatedAt),
+			UpdatedAt:      types.StringValue(s.UpdatedAt),
+		}
+
+		item.Labels, diags = types.MapValueFrom(ctx, types.StringType, s.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		item.GitRepo, diags = flattenComposeGitRepo(s.GitRepo)
+		resp.Diagnostics.Append(diags...)
+
+		item.Services, diags = flattenComposeServices(s.Services)
+		resp.Diagnostics.Append(diags...)
+
+		stacksList = append(stacksList, item)
+	}
+
+	stacksValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: composeStackDataAttrTypes}, stacksList)
+	resp.Diagnostics.Append(diags...)
+
+	// Set data
+	state := ComposeStacksDataSourceModel{
+		EnvironmentID: config.EnvironmentID,
+		Filter:        config.Filter,
+		Stacks:        stacksValue,
+		ID:            types.StringValue(config.EnvironmentID.ValueString()),
+	}
+
+	tflog.Trace(ctx, "Read compose stacks data source")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/ramorous/terraform-provider-dockhand/internal/compose"
+)
+
+// Ensure the implementations satisfy the expected interfaces
+var _ basetypes.StringTypable = ComposeYAMLType{}
+var _ basetypes.StringValuableWithSemanticEquals = ComposeYAML{}
+
+// ComposeYAMLType is a string type holding a compose document. Values that
+// differ only in formatting, key order, quoting style or comments are equal.
+type ComposeYAMLType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable name of the type.
+func (t ComposeYAMLType) String() string {
+	return "ComposeYAMLType"
+}
+
+// ValueType returns the value type of the type.
+func (t ComposeYAMLType) ValueType(_ context.Context) attr.Value {
+	return ComposeYAML{}
+}
+
+// Equal reports whether o is also a ComposeYAMLType.
+func (t ComposeYAMLType) Equal(o attr.Type) bool {
+	other, ok := o.(ComposeYAMLType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString wraps a string value.
+func (t ComposeYAMLType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ComposeYAML{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a ComposeYAML.
+func (t ComposeYAMLType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return ComposeYAML{StringValue: stringValue}, nil
+}
+
+// ComposeYAML is a compose document value.
+type ComposeYAML struct {
+	basetypes.StringValue
+}
+
+// NewComposeYAMLValue returns a known compose document value.
+func NewComposeYAMLValue(value string) ComposeYAML {
+	return ComposeYAML{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewComposeYAMLNull returns a null compose document value.
+func NewComposeYAMLNull() ComposeYAML {
+	return ComposeYAML{StringValue: basetypes.NewStringNull()}
+}
+
+// Type returns the type of the value.
+func (v ComposeYAML) Type(_ context.Context) attr.Type {
+	return ComposeYAMLType{}
+}
+
+// Equal reports whether o is a ComposeYAML with the same exact content.
+func (v ComposeYAML) Equal(o attr.Value) bool {
+	other, ok := o.(ComposeYAML)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether two compose documents describe the
+// same stack.
+func (v ComposeYAML) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ComposeYAML)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return compose.Equal(v.ValueString(), newValue.ValueString()), diags
+}
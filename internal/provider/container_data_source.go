@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &ContainerDataSource{}
+
+// NewContainerDataSource is a helper function to simplify the provider implementation.
+func NewContainerDataSource() datasource.DataSource {
+	return &ContainerDataSource{}
+}
+
+// ContainerDataSource is the data source implementation.
+type ContainerDataSource struct {
+	client *client.Client
+}
+
+// ContainerDataSourceModel describes the data source data model.
+type ContainerDataSourceModel struct {
+	ID            types.String  `tfsdk:"id"`
+	EnvironmentID types.String  `tfsdk:"environment_id"`
+	Name          types.String  `tfsdk:"name"`
+	Image         types.String  `tfsdk:"image"`
+	State         types.String  `tfsdk:"state"`
+	Status        types.String  `tfsdk:"status"`
+	Ports         types.List    `tfsdk:"ports"`
+	Mounts        types.List    `tfsdk:"mounts"`
+	Env           types.List    `tfsdk:"env"`
+	Labels        types.Map     `tfsdk:"labels"`
+	Command       types.String  `tfsdk:"command"`
+	Args          types.List    `tfsdk:"args"`
+	Memory        types.Int64   `tfsdk:"memory"`
+	CPUs          types.Float64 `tfsdk:"cpus"`
+	RestartPolicy types.String  `tfsdk:"restart_policy"`
+}
+
+// containerPortAttrTypes describes a port mapping object.
+var containerPortAttrTypes = map[string]attr.Type{
+	"private_port": types.Int64Type,
+	"public_port":  types.Int64Type,
+	"type":         types.StringType,
+	"ip":           types.StringType,
+}
+
+// containerMountAttrTypes describes a mount object.
+var containerMountAttrTypes = map[string]attr.Type{
+	"source":      types.StringType,
+	"destination": types.StringType,
+	"mode":        types.StringType,
+	"type":        types.StringType,
+}
+
+// Metadata returns the data source type name.
+func (d *ContainerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container"
+}
+
+// Schema defines the schema for the data source.
+func (d *ContainerDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single container in a Dockhand environment by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The container ID. Exactly one of `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The container name. Exactly one of `id` or `name` must be set.",
+			},
+			"image": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The image.",
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The state.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status.",
+			},
+			"ports": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Port mappings.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: containerPortSchemaAttributes(),
+				},
+			},
+			"mounts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Volume mounts.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: containerMountSchemaAttributes(),
+				},
+			},
+			"env": schema.ListAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Environment variables in `KEY=value` form, including those set through `env_sensitive`. Sensitive, since they often hold secrets.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels.",
+			},
+			"command": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The command.",
+			},
+			"args": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Command arguments.",
+			},
+			"memory": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Memory limit in bytes.",
+			},
+			"cpus": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "CPU limit.",
+			},
+			"restart_policy": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Restart policy.",
+			},
+		},
+	}
+}
+
+// containerPortSchemaAttributes returns the computed attributes of a port mapping.
+func containerPortSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"private_port": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The port inside the container.",
+		},
+		"public_port": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The port published on the host.",
+		},
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The protocol (tcp, udp).",
+		},
+		"ip": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The host IP the port is bound to.",
+		},
+	}
+}
+
+// containerMountSchemaAttributes returns the computed attributes of a mount.
+func containerMountSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"source": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The mount source.",
+		},
+		"destination": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The mount destination inside the container.",
+		},
+		"mode": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The mount mode.",
+		},
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The mount type.",
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ContainerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ContainerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ContainerDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	validateLookupKeys(config.ID, config.Name, "container", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := config.EnvironmentID.ValueString()
+
+	var container *client.Container
+	if !config.ID.IsNull() {
+		c, err := d.client.GetContainer(environmentID, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading container",
+				"Could not read container: "+err.Error(),
+			)
+			return
+		}
+		container = c
+	} else {
+		containers, err := d.client.ListContainers(environmentID, &client.ListFilter{Name: config.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading containers",
+				"Could not read containers: "+err.Error(),
+			)
+			return
+		}
+
+		container = lookupByName(containers, config.Name.ValueString(), func(c client.Container) string { return c.Name }, "container", &resp.Diagnostics)
+		if container == nil {
+			return
+		}
+	}
+
+	state := ContainerDataSourceModel{
+		ID:            types.StringValue(container.ID),
+		EnvironmentID: config.EnvironmentID,
+		Name:          types.StringValue(container.Name),
+		Image:         types.StringValue(container.Image),
+		State:         types.StringValue(container.State),
+		Status:        types.StringValue(container.Status),
+		Command:       types.StringValue(container.Command),
+		Memory:        types.Int64Value(container.Memory),
+		CPUs:          types.Float64Value(container.CPUs),
+		RestartPolicy: types.StringValue(container.Restart),
+	}
+
+	state.Ports, diags = flattenContainerPorts(container.Ports)
+	resp.Diagnostics.Append(diags...)
+
+	state.Mounts, diags = flattenContainerMounts(container.Mounts)
+	resp.Diagnostics.Append(diags...)
+
+	state.Env, diags = types.ListValueFrom(ctx, types.StringType, container.Env)
+	resp.Diagnostics.Append(diags...)
+
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, container.Labels)
+	resp.Diagnostics.Append(diags...)
+
+	state.Args, diags = types.ListValueFrom(ctx, types.StringType, container.Args)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read container data source", map[string]any{"id": container.ID})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenContainerPorts converts API port mappings into a list of objects.
+func flattenContainerPorts(ports []client.ContainerPort) (types.List, diag.Diagnostics) {
+	elems := make([]map[string]attr.Value, 0, len(ports))
+	for _, p := range ports {
+		elems = append(elems, map[string]attr.Value{
+			"private_port": types.Int64Value(int64(p.PrivatePort)),
+			"public_port":  types.Int64Value(int64(p.PublicPort)),
+			"type":         types.StringValue(p.Type),
+			"ip":           types.StringValue(p.IP),
+		})
+	}
+
+	return objectListValue(containerPortAttrTypes, elems)
+}
+
+// flattenContainerMounts converts API mounts into a list of objects.
+func flattenContainerMounts(mounts []client.ContainerMount) (types.List, diag.Diagnostics) {
+	elems := make([]map[string]attr.Value, 0, len(mounts))
+	for _, m := range mounts {
+		elems = append(elems, map[string]attr.Value{
+			"source":      types.StringValue(m.Source),
+			"destination": types.StringValue(m.Destination),
+			"mode":        types.StringValue(m.Mode),
+			"type":        types.StringValue(m.Type),
+		})
+	}
+
+	return objectListValue(containerMountAttrTypes, elems)
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// envKeyRegexp matches environment variable names accepted by Docker Compose.
+var envKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// validateEnv checks the keys of env and env_sensitive, and that no key is
+// set in both.
+func (m ContainerResourceModel) validateEnv(_ context.Context, diags *diag.Diagnostics) {
+	validateEnvMaps(m.Env, m.EnvSensitive, diags)
+}
+
+// applyEnv sets the container environment to env merged with env_sensitive.
+// The list is empty rather than nil when no variables are set, so an update
+// removes variables that were set before.
+func (m ContainerResourceModel) applyEnv(ctx context.Context, c *client.Container) diag.Diagnostics {
+	env, diags := mergeEnv(ctx, m.Env, m.EnvSensitive)
+	c.Env = envList(env)
+
+	return diags
+}
+
+// refreshEnv updates the configured environment variables from the API
+// container. Variables added by the image are ignored.
+func (m *ContainerResourceModel) refreshEnv(ctx context.Context, c *client.Container) diag.Diagnostics {
+	return refreshEnvMaps(ctx, parseEnvList(c.Env), &m.Env, &m.EnvSensitive)
+}
+
+// validateEnvMaps checks the keys of the env and env_sensitive attributes,
+// and that no key is set in both.
+func validateEnvMaps(plain, sensitive types.Map, diags *diag.Diagnostics) {
+	plainValues := knownStringMap(plain)
+	sensitiveValues := knownStringMap(sensitive)
+
+	for _, attr := range []struct {
+		name string
+		env  map[string]string
+	}{{"env", plainValues}, {"env_sensitive", sensitiveValues}} {
+		for k := range attr.env {
+			if !envKeyRegexp.MatchString(k) {
+				diags.AddAttributeError(path.Root(attr.name).AtMapKey(k), "Invalid environment variable name",
+					fmt.Sprintf("%q must start with a letter or underscore and contain only letters, digits, '_', '.' and '-'.", k))
+			}
+		}
+	}
+
+	for k := range sensitiveValues {
+		if _, ok := plainValues[k]; ok {
+			diags.AddAttributeError(path.Root("env_sensitive").AtMapKey(k), "Duplicate environment variable",
+				fmt.Sprintf("%q is set in both env and env_sensitive.", k))
+		}
+	}
+}
+
+// mergeEnv returns the variables of plain and sensitive in a single map.
+func mergeEnv(ctx context.Context, plain, sensitive types.Map) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	env := map[string]string{}
+	diags.Append(plain.ElementsAs(ctx, &env, false)...)
+
+	var sensitiveValues map[string]string
+	diags.Append(sensitive.ElementsAs(ctx, &sensitiveValues, false)...)
+	for k, v := range sensitiveValues {
+		env[k] = v
+	}
+
+	return env, diags
+}
+
+// refreshEnvMaps updates the keys configured in each map from remote. Each
+// value is written back only to the attribute that configured it, so
+// sensitive values never end up in a non-sensitive attribute. Nothing is
+// refreshed when remote is nil, which means the API did not report it.
+func refreshEnvMaps(ctx context.Context, remote map[string]string, maps ...*types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if remote == nil {
+		return diags
+	}
+
+	for _, current := range maps {
+		if current.IsNull() || current.IsUnknown() {
+			continue
+		}
+
+		var configured map[string]string
+		diags.Append(current.ElementsAs(ctx, &configured, false)...)
+
+		refreshed := make(map[string]string, len(configured))
+		for k := range configured {
+			if v, ok := remote[k]; ok {
+				refreshed[k] = v
+			}
+		}
+
+		var d diag.Diagnostics
+		*current, d = types.MapValueFrom(ctx, types.StringType, refreshed)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// knownStringMap returns the known elements of a map, or nil when the map is
+// null or unknown.
+func knownStringMap(m types.Map) map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+
+	values := map[string]string{}
+	for k, v := range m.Elements() {
+		if s, ok := v.(types.String); ok && !s.IsUnknown() {
+			values[k] = s.ValueString()
+		}
+	}
+
+	return values
+}
+
+// parseEnvList converts KEY=VALUE entries into a map. Entries without a value
+// map to an empty string.
+func parseEnvList(env []string) map[string]string {
+	values := make(map[string]string, len(env))
+	for _, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+		values[k] = v
+	}
+
+	return values
+}
+
+// parseEnvFile parses .env file content: KEY=VALUE lines, optionally
+// prefixed with "export", with blank lines and "#" comments ignored. Values
+// may be wrapped in single or double quotes.
+func parseEnvFile(content string) (map[string]string, error) {
+	values := map[string]string{}
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		k = strings.TrimSpace(k)
+		if !ok || !envKeyRegexp.MatchString(k) {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE, got %q", i+1, line)
+		}
+
+		v = strings.TrimSpace(v)
+		if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+			v = v[1 : len(v)-1]
+		}
+		values[k] = v
+	}
+
+	return values, nil
+}
+
+// upgradeContainerStateV0 converts env from a list of KEY=VALUE strings into
+// a map, and memory from bytes into a size string.
+func upgradeContainerStateV0(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	upgradeContainerState(req, resp, upgradeContainerEnv, upgradeContainerMemory)
+}
+
+// upgradeContainerStateV1 converts memory from bytes into a size string.
+func upgradeContainerStateV1(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	upgradeContainerState(req, resp, upgradeContainerMemory)
+}
+
+// upgradeContainerState applies upgrades to the raw prior container state.
+func upgradeContainerState(req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, upgrades ...func(map[string]any)) {
+	var raw map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"Could not parse prior container state: "+err.Error(),
+		)
+		return
+	}
+
+	for _, upgrade := range upgrades {
+		upgrade(raw)
+	}
+
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"Could not encode upgraded container state: "+err.Error(),
+		)
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// upgradeContainerEnv converts env from a list of KEY=VALUE strings into a map.
+func upgradeContainerEnv(raw map[string]any) {
+	if list, ok := raw["env"].([]any); ok {
+		env := make([]string, 0, len(list))
+		for _, e := range list {
+			if s, ok := e.(string); ok {
+				env = append(env, s)
+			}
+		}
+		raw["env"] = parseEnvList(env)
+	}
+}
+
+// upgradeContainerMemory converts memory from a number of bytes into a size
+// string holding the same number.
+func upgradeContainerMemory(raw map[string]any) {
+	if n, ok := raw["memory"].(json.Number); ok {
+		raw["memory"] = n.String()
+	}
+}
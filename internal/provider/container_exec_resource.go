@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a resource.Resource
+var _ resource.Resource = &ContainerExecResource{}
+var _ resource.ResourceWithValidateConfig = &ContainerExecResource{}
+
+// defaultExecTimeout is the number of seconds a command may run when timeout
+// is not set.
+const defaultExecTimeout = 600
+
+// NewContainerExecResource is a helper function to simplify the provider implementation.
+func NewContainerExecResource() resource.Resource {
+	return &ContainerExecResource{}
+}
+
+// ContainerExecResource is the resource implementation.
+type ContainerExecResource struct {
+	client *client.Client
+}
+
+// ContainerExecResourceModel describes the resource data model.
+type ContainerExecResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	ContainerID   types.String `tfsdk:"container_id"`
+	Command       types.List   `tfsdk:"command"`
+	Env           types.Map    `tfsdk:"env"`
+	User          types.String `tfsdk:"user"`
+	Workdir       types.String `tfsdk:"workdir"`
+	Triggers      types.Map    `tfsdk:"triggers"`
+	AllowFailure  types.Bool   `tfsdk:"allow_failure"`
+	Timeout       types.Int64  `tfsdk:"timeout"`
+	ExitCode      types.Int64  `tfsdk:"exit_code"`
+	Output        types.String `tfsdk:"output"`
+}
+
+// Metadata returns the resource type name.
+func (r *ContainerExecResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_exec"
+}
+
+// Schema defines the schema for the resource.
+func (r *ContainerExecResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a one-shot command inside a running container, such as a database migration. " +
+			"The command runs when the resource is created and again whenever any argument other than `allow_failure` and `timeout` changes. " +
+			"Destroying the resource does not undo anything.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The exec ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID where the container runs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID or name of the container to run the command in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"command": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The command and its arguments, e.g. `[\"sh\", \"-c\", \"./migrate up\"]`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"env": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional environment variables for the command.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The user to run the command as, in `user[:group]` form. Defaults to the container user.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"workdir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The working directory for the command. Defaults to the container working directory.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that cause the command to run again when they change.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"allow_failure": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Record a non-zero exit code instead of failing the apply. Defaults to `false`.",
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Seconds to wait for the command to finish, independent of the provider `timeout`. Defaults to %d.", defaultExecTimeout),
+			},
+			"exit_code": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The exit code of the command.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"output": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The captured standard output followed by standard error.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ContainerExecResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks the configuration before planning.
+func (r *ContainerExecResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ContainerExecResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if v := config.Timeout; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid timeout",
+			fmt.Sprintf("timeout must be a positive number of seconds, got %d.", v.ValueInt64()))
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ContainerExecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ContainerExecResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	execReq := &client.ExecConfig{
+		User:         plan.User.ValueString(),
+		WorkingDir:   plan.Workdir.ValueString(),
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+
+	// Convert Terraform list/map types to Go types
+	resp.Diagnostics.Append(plan.Command.ElementsAs(ctx, &execReq.Cmd, false)...)
+
+	var env map[string]string
+	resp.Diagnostics.Append(plan.Env.ElementsAs(ctx, &env, false)...)
+	execReq.Env = envList(env)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Run the command
+	timeout := int64(defaultExecTimeout)
+	if !plan.Timeout.IsNull() {
+		timeout = plan.Timeout.ValueInt64()
+	}
+	runCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	result, err := r.client.RunExec(runCtx, plan.EnvironmentID.ValueString(), plan.ContainerID.ValueString(), execReq)
+	if errors.Is(err, context.DeadlineExceeded) {
+		resp.Diagnostics.AddError(
+			"Command timed out",
+			fmt.Sprintf("The command did not finish within %d seconds. Raise timeout for long-running commands: %s", timeout, err.Error()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running command",
+			"Could not run command in container: "+err.Error(),
+		)
+		return
+	}
+
+	output := result.Stdout + result.Stderr
+
+	if result.ExitCode != 0 && !plan.AllowFailure.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Command failed",
+			fmt.Sprintf("The command exited with code %d. Set allow_failure to record the result instead.\n\nOutput:\n%s", result.ExitCode, output),
+		)
+		return
+	}
+
+	// Set state
+	plan.ID = types.StringValue(result.ID)
+	plan.ExitCode = types.Int64Value(int64(result.ExitCode))
+	plan.Output = types.StringValue(output)
+
+	tflog.Trace(ctx, "Ran container exec", map[string]any{"id": result.ID, "exit_code": result.ExitCode})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ContainerExecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ContainerExecResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The command has already run; its recorded result is kept as-is.
+	tflog.Trace(ctx, "Read container exec", map[string]any{"id": state.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state.
+func (r *ContainerExecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ContainerExecResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only allow_failure and timeout can change in place; they do not rerun
+	// the command.
+	tflog.Trace(ctx, "Updated container exec", map[string]any{"id": plan.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ContainerExecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ContainerExecResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to clean up; exec instances are removed with their container.
+	tflog.Trace(ctx, "Removed container exec from state", map[string]any{"id": state.ID.ValueString()})
+}
+
+// envList converts an environment map into sorted KEY=VALUE entries.
+func envList(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, k+"="+env[k])
+	}
+
+	return list
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// defaultContainerLogsTail is the number of lines returned when tail is not set.
+const defaultContainerLogsTail = 100
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &ContainerLogsDataSource{}
+
+// NewContainerLogsDataSource is a helper function to simplify the provider implementation.
+func NewContainerLogsDataSource() datasource.DataSource {
+	return &ContainerLogsDataSource{}
+}
+
+// ContainerLogsDataSource is the data source implementation.
+type ContainerLogsDataSource struct {
+	client *client.Client
+}
+
+// ContainerLogsDataSourceModel describes the data source data model.
+type ContainerLogsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	ContainerID   types.String `tfsdk:"container_id"`
+	Tail          types.Int64  `tfsdk:"tail"`
+	Since         types.String `tfsdk:"since"`
+	Timestamps    types.Bool   `tfsdk:"timestamps"`
+	Stdout        types.Bool   `tfsdk:"stdout"`
+	Stderr        types.Bool   `tfsdk:"stderr"`
+	Logs          types.String `tfsdk:"logs"`
+	Lines         types.List   `tfsdk:"lines"`
+}
+
+// Metadata returns the data source type name.
+func (d *ContainerLogsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_logs"
+}
+
+// Schema defines the schema for the data source.
+func (d *ContainerLogsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the logs of a container in a Dockhand environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The data source ID.",
+			},
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"container_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The container ID or name.",
+			},
+			"tail": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of lines to return from the end of the logs. Defaults to %d; set to `0` for all lines.", defaultContainerLogsTail),
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return logs since this RFC 3339 timestamp or relative duration (e.g. `10m`).",
+			},
+			"timestamps": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Prefix each line with its timestamp. Defaults to `false`.",
+			},
+			"stdout": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Include the standard output stream. Defaults to `true`.",
+			},
+			"stderr": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Include the standard error stream. Defaults to `true`.",
+			},
+			"logs": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The log output.",
+			},
+			"lines": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The log output split into lines.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ContainerLogsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ContainerLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ContainerLogsDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &client.ContainerLogsOptions{
+		Tail:       defaultContainerLogsTail,
+		Since:      config.Since.ValueString(),
+		Timestamps: config.Timestamps.ValueBool(),
+		Stdout:     config.Stdout.IsNull() || config.Stdout.ValueBool(),
+		Stderr:     config.Stderr.IsNull() || config.Stderr.ValueBool(),
+	}
+	if !config.Tail.IsNull() {
+		opts.Tail = int(config.Tail.ValueInt64())
+	}
+
+	if opts.Tail < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tail"),
+			"Invalid tail",
+			"tail must be zero or a positive number of lines.",
+		)
+	}
+	if !opts.Stdout && !opts.Stderr {
+		resp.Diagnostics.AddError(
+			"No log streams selected",
+			"At least one of stdout or stderr must be enabled.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logs, err := d.client.GetContainerLogs(config.EnvironmentID.ValueString(), config.ContainerID.ValueString(), opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading container logs",
+			"Could not read container logs: "+err.Error(),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(config.ContainerID.ValueString())
+	config.Logs = types.StringValue(logs)
+
+	config.Lines, diags = types.ListValueFrom(ctx, types.StringType, splitLogLines(logs))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read container logs data source", map[string]any{"container_id": config.ContainerID.ValueString()})
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// splitLogLines splits log output into lines, dropping the trailing newline.
+func splitLogLines(logs string) []string {
+	logs = strings.TrimRight(logs, "\r\n")
+	if logs == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.ReplaceAll(logs, "\r\n", "\n"), "\n")
+}
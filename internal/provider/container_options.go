@@ -0,0 +1,706 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+var (
+	dnsLabelRegexp   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	stopSignalRegexp = regexp.MustCompile(`^(SIG[A-Z0-9+-]+|[0-9]+)$`)
+	userRegexp       = regexp.MustCompile(`^[^:\s]+(:[^:\s]+)?$`)
+)
+
+// applyRuntimeOptions copies the runtime options of the plan onto the API
+// container.
+func (m ContainerResourceModel) applyRuntimeOptions(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c.User = m.User.ValueString()
+	c.WorkingDir = m.WorkingDir.ValueString()
+	c.Hostname = m.Hostname.ValueString()
+	c.Domainname = m.Domainname.ValueString()
+	c.Tty = m.Tty.ValueBool()
+	c.OpenStdin = m.StdinOpen.ValueBool()
+	c.StopSignal = m.StopSignal.ValueString()
+
+	if !m.StopTimeout.IsNull() {
+		timeout := int(m.StopTimeout.ValueInt64())
+		c.StopTimeout = &timeout
+	}
+
+	diags.Append(m.Entrypoint.ElementsAs(ctx, &c.Entrypoint, false)...)
+	diags.Append(m.DNS.ElementsAs(ctx, &c.DNS, false)...)
+	diags.Append(m.DNSSearch.ElementsAs(ctx, &c.DNSSearch, false)...)
+	diags.Append(m.ExtraHosts.ElementsAs(ctx, &c.ExtraHosts, false)...)
+
+	return diags
+}
+
+// refreshRuntimeOptions updates the configured runtime options from the API
+// container. Options that are not configured are left null so that image
+// defaults, such as the image user, do not show up as drift.
+func (m *ContainerResourceModel) refreshRuntimeOptions(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.User = refreshString(m.User, c.User)
+	m.WorkingDir = refreshString(m.WorkingDir, c.WorkingDir)
+	m.Hostname = refreshString(m.Hostname, c.Hostname)
+	m.Domainname = refreshString(m.Domainname, c.Domainname)
+	m.StopSignal = refreshString(m.StopSignal, c.StopSignal)
+
+	if !m.Tty.IsNull() {
+		m.Tty = types.BoolValue(c.Tty)
+	}
+	if !m.StdinOpen.IsNull() {
+		m.StdinOpen = types.BoolValue(c.OpenStdin)
+	}
+	if !m.StopTimeout.IsNull() && c.StopTimeout != nil {
+		m.StopTimeout = types.Int64Value(int64(*c.StopTimeout))
+	}
+
+	var d diag.Diagnostics
+	m.Entrypoint, d = refreshStringList(ctx, m.Entrypoint, c.Entrypoint)
+	diags.Append(d...)
+	m.DNS, d = refreshStringList(ctx, m.DNS, c.DNS)
+	diags.Append(d...)
+	m.DNSSearch, d = refreshStringList(ctx, m.DNSSearch, c.DNSSearch)
+	diags.Append(d...)
+	m.ExtraHosts, d = refreshStringList(ctx, m.ExtraHosts, c.ExtraHosts)
+	diags.Append(d...)
+
+	return diags
+}
+
+// validateRuntimeOptions checks the known runtime options of the configuration.
+func (m ContainerResourceModel) validateRuntimeOptions(ctx context.Context, diags *diag.Diagnostics) {
+	if v := m.User; !v.IsNull() && !v.IsUnknown() && !userRegexp.MatchString(v.ValueString()) {
+		diags.AddAttributeError(path.Root("user"), "Invalid user",
+			fmt.Sprintf("user must be in user[:group] form, got %q.", v.ValueString()))
+	}
+
+	if v := m.WorkingDir; !v.IsNull() && !v.IsUnknown() && !strings.HasPrefix(v.ValueString(), "/") {
+		diags.AddAttributeError(path.Root("working_dir"), "Invalid working directory",
+			fmt.Sprintf("working_dir must be an absolute path, got %q.", v.ValueString()))
+	}
+
+	if v := m.Hostname; !v.IsNull() && !v.IsUnknown() && !validDomainName(v.ValueString()) {
+		diags.AddAttributeError(path.Root("hostname"), "Invalid hostname",
+			fmt.Sprintf("hostname must consist of DNS labels of letters, digits and hyphens, got %q.", v.ValueString()))
+	}
+
+	if v := m.Domainname; !v.IsNull() && !v.IsUnknown() && !validDomainName(v.ValueString()) {
+		diags.AddAttributeError(path.Root("domainname"), "Invalid domain name",
+			fmt.Sprintf("%q is not a valid domain name.", v.ValueString()))
+	}
+
+	if v := m.StopSignal; !v.IsNull() && !v.IsUnknown() && !stopSignalRegexp.MatchString(v.ValueString()) {
+		diags.AddAttributeError(path.Root("stop_signal"), "Invalid stop signal",
+			fmt.Sprintf("stop_signal must be a signal name such as SIGTERM or a signal number, got %q.", v.ValueString()))
+	}
+
+	if v := m.StopTimeout; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() < -1 {
+		diags.AddAttributeError(path.Root("stop_timeout"), "Invalid stop timeout",
+			"stop_timeout must be a number of seconds, or -1 to wait indefinitely.")
+	}
+
+	for i, dns := range knownStrings(ctx, m.DNS, diags) {
+		if net.ParseIP(dns) == nil {
+			diags.AddAttributeError(path.Root("dns").AtListIndex(i), "Invalid DNS server",
+				fmt.Sprintf("%q is not an IP address.", dns))
+		}
+	}
+
+	for i, domain := range knownStrings(ctx, m.DNSSearch, diags) {
+		if !validDomainName(domain) {
+			diags.AddAttributeError(path.Root("dns_search").AtListIndex(i), "Invalid DNS search domain",
+				fmt.Sprintf("%q is not a valid domain name.", domain))
+		}
+	}
+
+	for i, entry := range knownStrings(ctx, m.ExtraHosts, diags) {
+		if err := validateExtraHost(entry); err != nil {
+			diags.AddAttributeError(path.Root("extra_hosts").AtListIndex(i), "Invalid extra host", err.Error())
+		}
+	}
+}
+
+// validateExtraHost checks a "host:ip" entry. The address may be an IPv4 or
+// IPv6 address, or the special value host-gateway.
+func validateExtraHost(entry string) error {
+	host, ip, ok := strings.Cut(entry, ":")
+	if !ok || host == "" || ip == "" {
+		return fmt.Errorf("extra host entries must be in host:ip form, got %q", entry)
+	}
+
+	if ip != "host-gateway" && net.ParseIP(strings.Trim(ip, "[]")) == nil {
+		return fmt.Errorf("%q in extra host entry %q is not an IP address", ip, entry)
+	}
+
+	return nil
+}
+
+// validDomainName reports whether s is a dot-separated list of DNS labels.
+func validDomainName(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(strings.TrimSuffix(s, "."), ".") {
+		if !dnsLabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// knownStrings returns the elements of a list when it is fully known, and
+// nil otherwise.
+func knownStrings(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	for _, e := range list.Elements() {
+		if e.IsUnknown() {
+			return nil
+		}
+	}
+
+	var values []string
+	diags.Append(list.ElementsAs(ctx, &values, false)...)
+
+	return values
+}
+
+// refreshString returns remote when current is configured, and null otherwise.
+func refreshString(current types.String, remote string) types.String {
+	if current.IsNull() {
+		return current
+	}
+
+	return types.StringValue(remote)
+}
+
+// refreshStringList returns remote when current is configured, and null otherwise.
+func refreshStringList(ctx context.Context, current types.List, remote []string) (types.List, diag.Diagnostics) {
+	if current.IsNull() {
+		return current, nil
+	}
+
+	if remote == nil {
+		remote = []string{}
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, remote)
+}
+
+// noNewPrivilegesOpt is the security option set by no_new_privileges.
+const noNewPrivilegesOpt = "no-new-privileges:true"
+
+var (
+	capabilityRegexp = regexp.MustCompile(`^(CAP_)?[A-Z_]+$`)
+	sysctlRegexp     = regexp.MustCompile(`^[a-z0-9_]+([./][a-z0-9_-]+)+$`)
+)
+
+// dockerSocketPaths are host paths that expose the Docker daemon.
+var dockerSocketPaths = []string{"/var/run/docker.sock", "/run/docker.sock"}
+
+// ContainerCapabilitiesModel describes the capabilities attribute.
+type ContainerCapabilitiesModel struct {
+	Add  types.List `tfsdk:"add"`
+	Drop types.List `tfsdk:"drop"`
+}
+
+// containerCapabilitiesAttrTypes describes a capabilities object.
+var containerCapabilitiesAttrTypes = map[string]attr.Type{
+	"add":  types.ListType{ElemType: types.StringType},
+	"drop": types.ListType{ElemType: types.StringType},
+}
+
+// applySecurityOptions copies the security options of the plan onto the API
+// container.
+func (m ContainerResourceModel) applySecurityOptions(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c.Privileged = m.Privileged.ValueBool()
+	c.ReadOnly = m.ReadOnly.ValueBool()
+	c.UsernsMode = m.UsernsMode.ValueString()
+
+	if !m.Capabilities.IsNull() {
+		var caps ContainerCapabilitiesModel
+		diags.Append(m.Capabilities.As(ctx, &caps, basetypes.ObjectAsOptions{})...)
+		diags.Append(caps.Add.ElementsAs(ctx, &c.CapAdd, false)...)
+		diags.Append(caps.Drop.ElementsAs(ctx, &c.CapDrop, false)...)
+	}
+
+	diags.Append(m.SecurityOpts.ElementsAs(ctx, &c.SecurityOpt, false)...)
+	if m.NoNewPrivileges.ValueBool() {
+		c.SecurityOpt = append(c.SecurityOpt, noNewPrivilegesOpt)
+	}
+
+	diags.Append(m.Sysctls.ElementsAs(ctx, &c.Sysctls, false)...)
+
+	return diags
+}
+
+// refreshSecurityOptions updates the configured security options from the
+// API container.
+func (m *ContainerResourceModel) refreshSecurityOptions(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.Privileged.IsNull() {
+		m.Privileged = types.BoolValue(c.Privileged)
+	}
+	if !m.ReadOnly.IsNull() {
+		m.ReadOnly = types.BoolValue(c.ReadOnly)
+	}
+	m.UsernsMode = refreshString(m.UsernsMode, c.UsernsMode)
+
+	// no_new_privileges is reported as one of the security options
+	securityOpts := make([]string, 0, len(c.SecurityOpt))
+	noNewPrivileges := false
+	for _, opt := range c.SecurityOpt {
+		if opt == noNewPrivilegesOpt || opt == "no-new-privileges" {
+			noNewPrivileges = true
+			continue
+		}
+		securityOpts = append(securityOpts, opt)
+	}
+	if !m.NoNewPrivileges.IsNull() {
+		m.NoNewPrivileges = types.BoolValue(noNewPrivileges)
+	}
+
+	var d diag.Diagnostics
+	m.SecurityOpts, d = refreshStringList(ctx, m.SecurityOpts, securityOpts)
+	diags.Append(d...)
+
+	if !m.Sysctls.IsNull() {
+		m.Sysctls, d = types.MapValueFrom(ctx, types.StringType, c.Sysctls)
+		diags.Append(d...)
+	}
+
+	if !m.Capabilities.IsNull() {
+		var caps ContainerCapabilitiesModel
+		diags.Append(m.Capabilities.As(ctx, &caps, basetypes.ObjectAsOptions{})...)
+
+		caps.Add, d = refreshStringList(ctx, caps.Add, c.CapAdd)
+		diags.Append(d...)
+		caps.Drop, d = refreshStringList(ctx, caps.Drop, c.CapDrop)
+		diags.Append(d...)
+
+		m.Capabilities, d = types.ObjectValueFrom(ctx, containerCapabilitiesAttrTypes, caps)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// validateSecurityOptions checks the known security options of the
+// configuration and warns about settings that grant host-level access.
+func (m ContainerResourceModel) validateSecurityOptions(ctx context.Context, diags *diag.Diagnostics) {
+	if m.Privileged.ValueBool() {
+		diags.AddAttributeWarning(path.Root("privileged"), "Privileged container",
+			"The container will run with all capabilities and full access to host devices. "+
+				"Prefer adding the specific capabilities it needs.")
+	}
+
+	for i, mount := range knownStrings(ctx, m.Mounts, diags) {
+		source, _, _ := strings.Cut(mount, ":")
+		if slices.Contains(dockerSocketPaths, source) {
+			diags.AddAttributeWarning(path.Root("mounts").AtListIndex(i), "Docker socket mounted",
+				"Mounting the Docker socket gives the container root-equivalent control of the host.")
+		}
+	}
+
+	for i, opt := range knownStrings(ctx, m.SecurityOpts, diags) {
+		// Docker reports it among the security options, so it can only be
+		// told apart from them as no_new_privileges
+		if strings.HasPrefix(opt, "no-new-privileges") {
+			diags.AddAttributeError(path.Root("security_opts").AtListIndex(i), "Invalid security option",
+				"Set no_new_privileges = true instead of listing no-new-privileges in security_opts.")
+			continue
+		}
+		if !strings.ContainsAny(opt, "=:") {
+			diags.AddAttributeError(path.Root("security_opts").AtListIndex(i), "Invalid security option",
+				fmt.Sprintf("Security options must be in key=value form, such as seccomp=unconfined, got %q.", opt))
+		}
+	}
+
+	if !m.Sysctls.IsNull() && !m.Sysctls.IsUnknown() {
+		for key := range m.Sysctls.Elements() {
+			if !sysctlRegexp.MatchString(key) {
+				diags.AddAttributeError(path.Root("sysctls").AtMapKey(key), "Invalid sysctl",
+					fmt.Sprintf("%q is not a valid sysctl name, such as net.core.somaxconn.", key))
+			}
+		}
+	}
+
+	if !m.Capabilities.IsNull() && !m.Capabilities.IsUnknown() {
+		var caps ContainerCapabilitiesModel
+		diags.Append(m.Capabilities.As(ctx, &caps, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+
+		for name, list := range map[string]types.List{"add": caps.Add, "drop": caps.Drop} {
+			for i, capability := range knownStrings(ctx, list, diags) {
+				if capability != "ALL" && !capabilityRegexp.MatchString(capability) {
+					diags.AddAttributeError(path.Root("capabilities").AtName(name).AtListIndex(i), "Invalid capability",
+						fmt.Sprintf("%q is not a capability name, such as NET_ADMIN or CAP_SYS_TIME.", capability))
+				}
+			}
+		}
+	}
+}
+
+var (
+	byteSizeRegexp   = regexp.MustCompile(`^(?i)(\d+(?:\.\d+)?)\s*([kmgt]?)b?$`)
+	cpusetRegexp     = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+	byteSizeFactors  = map[string]float64{"": 1, "k": 1 << 10, "m": 1 << 20, "g": 1 << 30, "t": 1 << 40}
+	knownUlimitNames = []string{
+		"core", "cpu", "data", "fsize", "locks", "memlock", "msgqueue", "nice",
+		"nofile", "nproc", "rss", "rtprio", "rttime", "sigpending", "stack",
+	}
+)
+
+// ContainerUlimitModel describes an entry of the ulimits attribute.
+type ContainerUlimitModel struct {
+	Name types.String `tfsdk:"name"`
+	Soft types.Int64  `tfsdk:"soft"`
+	Hard types.Int64  `tfsdk:"hard"`
+}
+
+// containerUlimitAttrTypes describes a ulimit object.
+var containerUlimitAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"soft": types.Int64Type,
+	"hard": types.Int64Type,
+}
+
+// parseByteSize parses a size such as "512m", "2g" or "1048576" into bytes.
+// Units are binary, so "1k" is 1024 bytes.
+func parseByteSize(s string) (int64, error) {
+	match := byteSizeRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a size; use a number of bytes or a number followed by k, m, g or t, such as \"512m\"", s)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a size: %w", s, err)
+	}
+
+	bytes := value * byteSizeFactors[strings.ToLower(match[2])]
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer fits
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("%q is too large; sizes must be below 8 exabytes", s)
+	}
+
+	return int64(bytes), nil
+}
+
+// applyResourceLimits copies the resource limits of the plan onto the API
+// container. Sizes must already have been validated.
+func (m ContainerResourceModel) applyResourceLimits(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c.CPUShares = m.CPUShares.ValueInt64()
+	c.CpusetCpus = m.CpusetCpus.ValueString()
+
+	if !m.PidsLimit.IsNull() {
+		limit := m.PidsLimit.ValueInt64()
+		c.PidsLimit = &limit
+	}
+
+	for _, size := range []struct {
+		name  string
+		value types.String
+		dest  *int64
+	}{
+		{"memory", m.Memory, &c.Memory},
+		{"memory_reservation", m.MemoryReservation, &c.MemoryReservation},
+		{"memory_swap", m.MemorySwap, &c.MemorySwap},
+		{"shm_size", m.ShmSize, &c.ShmSize},
+	} {
+		if size.value.IsNull() {
+			continue
+		}
+		if size.name == "memory_swap" && size.value.ValueString() == "-1" {
+			*size.dest = -1
+			continue
+		}
+
+		bytes, err := parseByteSize(size.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(size.name), "Invalid size", err.Error())
+			continue
+		}
+		*size.dest = bytes
+	}
+
+	var ulimits []ContainerUlimitModel
+	diags.Append(m.Ulimits.ElementsAs(ctx, &ulimits, false)...)
+	for _, u := range ulimits {
+		c.Ulimits = append(c.Ulimits, client.Ulimit{
+			Name: u.Name.ValueString(),
+			Soft: u.Soft.ValueInt64(),
+			Hard: u.Hard.ValueInt64(),
+		})
+	}
+
+	return diags
+}
+
+// refreshResourceLimits updates the configured resource limits from the API
+// container. Sizes that still match the configured string are kept as
+// written; otherwise the remote value is recorded in bytes.
+func (m *ContainerResourceModel) refreshResourceLimits(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.CPUShares.IsNull() {
+		m.CPUShares = types.Int64Value(c.CPUShares)
+	}
+	m.CpusetCpus = refreshString(m.CpusetCpus, c.CpusetCpus)
+	if !m.PidsLimit.IsNull() && c.PidsLimit != nil {
+		m.PidsLimit = types.Int64Value(*c.PidsLimit)
+	}
+
+	m.Memory = refreshByteSize(m.Memory, c.Memory)
+	m.MemoryReservation = refreshByteSize(m.MemoryReservation, c.MemoryReservation)
+	m.MemorySwap = refreshByteSize(m.MemorySwap, c.MemorySwap)
+	m.ShmSize = refreshByteSize(m.ShmSize, c.ShmSize)
+
+	if !m.Ulimits.IsNull() {
+		ulimits := make([]ContainerUlimitModel, 0, len(c.Ulimits))
+		for _, u := range c.Ulimits {
+			ulimits = append(ulimits, ContainerUlimitModel{
+				Name: types.StringValue(u.Name),
+				Soft: types.Int64Value(u.Soft),
+				Hard: types.Int64Value(u.Hard),
+			})
+		}
+
+		var d diag.Diagnostics
+		m.Ulimits, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: containerUlimitAttrTypes}, ulimits)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// refreshByteSize returns current when it still describes remote bytes, the
+// remote byte count when it does not, and null when current is not configured.
+func refreshByteSize(current types.String, remote int64) types.String {
+	if current.IsNull() {
+		return current
+	}
+
+	if current.ValueString() == "-1" && remote == -1 {
+		return current
+	}
+	if bytes, err := parseByteSize(current.ValueString()); err == nil && bytes == remote {
+		return current
+	}
+
+	return types.StringValue(strconv.FormatInt(remote, 10))
+}
+
+// validateResourceLimits checks the known resource limits of the configuration.
+func (m ContainerResourceModel) validateResourceLimits(ctx context.Context, diags *diag.Diagnostics) {
+	sizes := map[string]types.String{
+		"memory":             m.Memory,
+		"memory_reservation": m.MemoryReservation,
+		"memory_swap":        m.MemorySwap,
+		"shm_size":           m.ShmSize,
+	}
+	for name, v := range sizes {
+		if v.IsNull() || v.IsUnknown() || (name == "memory_swap" && v.ValueString() == "-1") {
+			continue
+		}
+		if _, err := parseByteSize(v.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root(name), "Invalid size", err.Error())
+		}
+	}
+
+	if v := m.CPUShares; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() < 2 {
+		diags.AddAttributeError(path.Root("cpu_shares"), "Invalid CPU shares",
+			"cpu_shares must be at least 2; the default weight is 1024.")
+	}
+
+	if v := m.CpusetCpus; !v.IsNull() && !v.IsUnknown() && !cpusetRegexp.MatchString(v.ValueString()) {
+		diags.AddAttributeError(path.Root("cpuset_cpus"), "Invalid cpuset",
+			fmt.Sprintf("cpuset_cpus must be a list or range of CPUs such as \"0-3\" or \"0,2\", got %q.", v.ValueString()))
+	}
+
+	if v := m.PidsLimit; !v.IsNull() && !v.IsUnknown() && v.ValueInt64() < -1 {
+		diags.AddAttributeError(path.Root("pids_limit"), "Invalid PIDs limit",
+			"pids_limit must be a positive number of processes, or -1 for no limit.")
+	}
+
+	if m.Ulimits.IsNull() || m.Ulimits.IsUnknown() {
+		return
+	}
+
+	var ulimits []ContainerUlimitModel
+	diags.Append(m.Ulimits.ElementsAs(ctx, &ulimits, false)...)
+	for i, u := range ulimits {
+		at := path.Root("ulimits").AtListIndex(i)
+
+		if !u.Name.IsUnknown() && !slices.Contains(knownUlimitNames, u.Name.ValueString()) {
+			diags.AddAttributeError(at.AtName("name"), "Invalid ulimit",
+				fmt.Sprintf("%q is not a ulimit name; expected one of %s.", u.Name.ValueString(), strings.Join(knownUlimitNames, ", ")))
+		}
+
+		if !u.Soft.IsUnknown() && !u.Hard.IsUnknown() && u.Hard.ValueInt64() != -1 &&
+			(u.Soft.ValueInt64() == -1 || u.Soft.ValueInt64() > u.Hard.ValueInt64()) {
+			diags.AddAttributeError(at.AtName("soft"), "Invalid ulimit",
+				"The soft limit must not exceed the hard limit.")
+		}
+	}
+}
+
+var (
+	logAddressRegexp = regexp.MustCompile(`^(udp|tcp|tcp\+tls|unix|unixgram)://\S+$`)
+	logMaxFileRegexp = regexp.MustCompile(`^[1-9][0-9]*$`)
+)
+
+// logDriverRequiredOpts lists the built-in logging drivers and the options
+// each one requires.
+var logDriverRequiredOpts = map[string][]string{
+	"json-file":  nil,
+	"local":      nil,
+	"journald":   nil,
+	"syslog":     nil,
+	"gelf":       {"gelf-address"},
+	"fluentd":    nil,
+	"awslogs":    {"awslogs-group"},
+	"splunk":     {"splunk-token", "splunk-url"},
+	"etwlogs":    nil,
+	"gcplogs":    nil,
+	"logentries": {"logentries-token"},
+	"loki":       {"loki-url"},
+	"none":       nil,
+}
+
+// applyLogConfig copies the logging configuration of the plan onto the API
+// container.
+func (m ContainerResourceModel) applyLogConfig(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if m.LogDriver.IsNull() {
+		return diags
+	}
+
+	c.LogConfig = &client.LogConfig{Type: m.LogDriver.ValueString()}
+	diags.Append(m.LogOpts.ElementsAs(ctx, &c.LogConfig.Config, false)...)
+
+	return diags
+}
+
+// refreshLogConfig updates the configured logging configuration from the API
+// container.
+func (m *ContainerResourceModel) refreshLogConfig(ctx context.Context, c *client.Container) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	logConfig := c.LogConfig
+	if logConfig == nil {
+		logConfig = &client.LogConfig{}
+	}
+
+	m.LogDriver = refreshString(m.LogDriver, logConfig.Type)
+
+	if !m.LogOpts.IsNull() {
+		var d diag.Diagnostics
+		m.LogOpts, d = types.MapValueFrom(ctx, types.StringType, logConfig.Config)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// validateLogConfig checks the logging driver and the options it requires.
+// Unknown drivers are allowed, since they may be plugins, but produce a
+// warning.
+func (m ContainerResourceModel) validateLogConfig(ctx context.Context, diags *diag.Diagnostics) {
+	if m.LogDriver.IsUnknown() || m.LogOpts.IsUnknown() {
+		return
+	}
+
+	var opts map[string]string
+	if !m.LogOpts.IsNull() {
+		diags.Append(m.LogOpts.ElementsAs(ctx, &opts, false)...)
+	}
+
+	if m.LogDriver.IsNull() {
+		if len(opts) > 0 {
+			diags.AddAttributeError(path.Root("log_opts"), "Missing log driver",
+				"log_opts requires log_driver to be set.")
+		}
+		return
+	}
+
+	driver := m.LogDriver.ValueString()
+	required, known := logDriverRequiredOpts[driver]
+	if !known {
+		diags.AddAttributeWarning(path.Root("log_driver"), "Unknown log driver",
+			fmt.Sprintf("%q is not a built-in logging driver; make sure the plugin is installed on the Docker host.", driver))
+	}
+
+	for _, key := range required {
+		if opts[key] == "" {
+			diags.AddAttributeError(path.Root("log_opts"), "Missing log option",
+				fmt.Sprintf("The %s log driver requires the %q option.", driver, key))
+		}
+	}
+
+	if driver == "none" && len(opts) > 0 {
+		diags.AddAttributeError(path.Root("log_opts"), "Invalid log option",
+			"The none log driver does not take options.")
+	}
+
+	for key, value := range opts {
+		if err := validateLogOpt(driver, key, value); err != nil {
+			diags.AddAttributeError(path.Root("log_opts").AtMapKey(key), "Invalid log option", err.Error())
+		}
+	}
+}
+
+// validateLogOpt checks the format of a single log option.
+func validateLogOpt(driver, key, value string) error {
+	switch {
+	case key == "max-size" && (driver == "json-file" || driver == "local"):
+		if _, err := parseByteSize(value); err != nil {
+			return fmt.Errorf("max-size must be a size such as \"10m\": %w", err)
+		}
+	case key == "max-file" && (driver == "json-file" || driver == "local"):
+		if !logMaxFileRegexp.MatchString(value) {
+			return fmt.Errorf("max-file must be a positive number of files, got %q", value)
+		}
+	case key == "syslog-address", key == "fluentd-address" && strings.Contains(value, "://"):
+		if !logAddressRegexp.MatchString(value) {
+			return fmt.Errorf("%s must be a URL such as udp://host:514 or unix:///dev/log, got %q", key, value)
+		}
+	case key == "gelf-address":
+		if !strings.HasPrefix(value, "udp://") && !strings.HasPrefix(value, "tcp://") {
+			return fmt.Errorf("gelf-address must start with udp:// or tcp://, got %q", value)
+		}
+	case key == "loki-url" || key == "splunk-url":
+		if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("%s must be an http or https URL, got %q", key, value)
+		}
+	}
+
+	return nil
+}
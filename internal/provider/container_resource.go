@@ -3,9 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -13,8 +19,15 @@ import (
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
 
+// containerFailureLogLines is the number of log lines included in diagnostics
+// when a container fails to come up.
+const containerFailureLogLines = 20
+
 // Ensure the implementation defined in this package is a resource.Resource
 var _ resource.Resource = &ContainerResource{}
+var _ resource.ResourceWithValidateConfig = &ContainerResource{}
+var _ resource.ResourceWithModifyPlan = &ContainerResource{}
+var _ resource.ResourceWithUpgradeState = &ContainerResource{}
 
 // NewContainerResource is a helper function to simplify the provider implementation.
 func NewContainerResource() resource.Resource {
@@ -28,21 +41,55 @@ type ContainerResource struct {
 
 // ContainerResourceModel describes the resource data model.
 type ContainerResourceModel struct {
-	ID            types.String  `tfsdk:"id"`
-	EnvironmentID types.String  `tfsdk:"environment_id"`
-	Name          types.String  `tfsdk:"name"`
-	Image         types.String  `tfsdk:"image"`
-	State         types.String  `tfsdk:"state"`
-	Status        types.String  `tfsdk:"status"`
-	Ports         types.List    `tfsdk:"ports"`
-	Mounts        types.List    `tfsdk:"mounts"`
-	Env           types.List    `tfsdk:"env"`
-	Labels        types.Map     `tfsdk:"labels"`
-	Command       types.String  `tfsdk:"command"`
-	Args          types.List    `tfsdk:"args"`
-	Memory        types.Int64   `tfsdk:"memory"`
-	CPUs          types.Float64 `tfsdk:"cpus"`
-	RestartPolicy types.String  `tfsdk:"restart_policy"`
+	ID                types.String  `tfsdk:"id"`
+	EnvironmentID     types.String  `tfsdk:"environment_id"`
+	Name              types.String  `tfsdk:"name"`
+	Image             types.String  `tfsdk:"image"`
+	State             types.String  `tfsdk:"state"`
+	Status            types.String  `tfsdk:"status"`
+	Ports             types.List    `tfsdk:"ports"`
+	Mounts            types.List    `tfsdk:"mounts"`
+	Env               types.Map     `tfsdk:"env"`
+	EnvSensitive      types.Map     `tfsdk:"env_sensitive"`
+	Triggers          types.Map     `tfsdk:"triggers"`
+	TriggerAction     types.String  `tfsdk:"trigger_action"`
+	Labels            types.Map     `tfsdk:"labels"`
+	Command           types.String  `tfsdk:"command"`
+	Args              types.List    `tfsdk:"args"`
+	Memory            types.String  `tfsdk:"memory"`
+	CPUs              types.Float64 `tfsdk:"cpus"`
+	RestartPolicy     types.String  `tfsdk:"restart_policy"`
+	Upload            types.List    `tfsdk:"upload"`
+	Entrypoint        types.List    `tfsdk:"entrypoint"`
+	User              types.String  `tfsdk:"user"`
+	WorkingDir        types.String  `tfsdk:"working_dir"`
+	Hostname          types.String  `tfsdk:"hostname"`
+	Domainname        types.String  `tfsdk:"domainname"`
+	DNS               types.List    `tfsdk:"dns"`
+	DNSSearch         types.List    `tfsdk:"dns_search"`
+	ExtraHosts        types.List    `tfsdk:"extra_hosts"`
+	Tty               types.Bool    `tfsdk:"tty"`
+	StdinOpen         types.Bool    `tfsdk:"stdin_open"`
+	StopSignal        types.String  `tfsdk:"stop_signal"`
+	StopTimeout       types.Int64   `tfsdk:"stop_timeout"`
+	RemoveVolumes     types.Bool    `tfsdk:"remove_volumes"`
+	Force             types.Bool    `tfsdk:"force"`
+	Capabilities      types.Object  `tfsdk:"capabilities"`
+	Privileged        types.Bool    `tfsdk:"privileged"`
+	ReadOnly          types.Bool    `tfsdk:"read_only"`
+	SecurityOpts      types.List    `tfsdk:"security_opts"`
+	NoNewPrivileges   types.Bool    `tfsdk:"no_new_privileges"`
+	UsernsMode        types.String  `tfsdk:"userns_mode"`
+	Sysctls           types.Map     `tfsdk:"sysctls"`
+	MemoryReservation types.String  `tfsdk:"memory_reservation"`
+	MemorySwap        types.String  `tfsdk:"memory_swap"`
+	CPUShares         types.Int64   `tfsdk:"cpu_shares"`
+	CpusetCpus        types.String  `tfsdk:"cpuset_cpus"`
+	PidsLimit         types.Int64   `tfsdk:"pids_limit"`
+	ShmSize           types.String  `tfsdk:"shm_size"`
+	Ulimits           types.List    `tfsdk:"ulimits"`
+	LogDriver         types.String  `tfsdk:"log_driver"`
+	LogOpts           types.Map     `tfsdk:"log_opts"`
 }
 
 // Metadata returns the resource type name.
@@ -53,6 +100,7 @@ func (r *ContainerResource) Metadata(_ context.Context, req resource.MetadataReq
 // Schema defines the schema for the resource.
 func (r *ContainerResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             2,
 		MarkdownDescription: "Manages a Docker container in Dockhand.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -92,16 +140,24 @@ func (r *ContainerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				ElementType:         types.StringType,
 				MarkdownDescription: "Volume mounts for the container.",
 			},
-			"env": schema.ListAttribute{
+			"env": schema.MapAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
 				MarkdownDescription: "Environment variables for the container.",
 			},
+			"env_sensitive": schema.MapAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Environment variables whose values are hidden from plan output, such as passwords. Merged with `env`; a key may not be set in both.",
+			},
 			"labels": schema.MapAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
 				MarkdownDescription: "Labels for the container.",
 			},
+			"triggers":       triggersAttribute("container"),
+			"trigger_action": triggerActionAttribute(),
 			"command": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The command to run in the container.",
@@ -111,9 +167,9 @@ func (r *ContainerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				ElementType:         types.StringType,
 				MarkdownDescription: "Arguments for the container command.",
 			},
-			"memory": schema.Int64Attribute{
+			"memory": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Memory limit in bytes for the container.",
+				MarkdownDescription: "Memory limit, as bytes or a size such as `512m`. Updated in place.",
 			},
 			"cpus": schema.Float64Attribute{
 				Optional:            true,
@@ -123,6 +179,174 @@ func (r *ContainerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				Optional:            true,
 				MarkdownDescription: "Restart policy for the container (no, always, on-failure, unless-stopped).",
 			},
+			"upload": containerUploadAttribute(),
+			"entrypoint": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Entrypoint for the container, overriding the image entrypoint.",
+			},
+			"user": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "User the container runs as, in `user[:group]` form by name or numeric ID.",
+			},
+			"working_dir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Absolute working directory for the container command.",
+			},
+			"hostname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Hostname of the container.",
+			},
+			"domainname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Domain name of the container.",
+			},
+			"dns": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IP addresses of custom DNS servers.",
+			},
+			"dns_search": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Custom DNS search domains.",
+			},
+			"extra_hosts": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional `/etc/hosts` entries in `host:ip` form. The address may be `host-gateway`.",
+			},
+			"tty": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Allocate a pseudo-TTY.",
+			},
+			"stdin_open": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Keep standard input open even when not attached.",
+			},
+			"stop_signal": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Signal used to stop the container, such as `SIGTERM` or `SIGQUIT`.",
+			},
+			"stop_timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Seconds to wait for the container to stop before killing it. `-1` waits indefinitely. Also used when the container is destroyed.",
+			},
+			"remove_volumes": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove anonymous volumes attached to the container when it is destroyed. Defaults to `false`.",
+			},
+			"force": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the container on destroy even if it fails to stop gracefully. Defaults to `false`.",
+			},
+			"capabilities": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Linux capabilities to add to or drop from the default set.",
+				Attributes: map[string]schema.Attribute{
+					"add": schema.ListAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Capabilities to add, such as `NET_ADMIN`.",
+					},
+					"drop": schema.ListAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Capabilities to drop, such as `ALL`.",
+					},
+				},
+			},
+			"privileged": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Run the container in privileged mode. A warning is shown when enabled.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Mount the container root filesystem as read-only.",
+			},
+			"security_opts": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Security options such as `seccomp=unconfined` or `apparmor=my-profile`. Use `no_new_privileges` rather than listing `no-new-privileges` here.",
+			},
+			"no_new_privileges": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Prevent processes from gaining additional privileges.",
+			},
+			"userns_mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "User namespace mode, passed to Docker as is. Set to `host` to disable user namespace remapping.",
+			},
+			"sysctls": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Namespaced kernel parameters, such as `net.core.somaxconn`.",
+			},
+			"memory_reservation": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Soft memory limit, as bytes or a size such as `256m`. Updated in place.",
+			},
+			"memory_swap": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Total memory plus swap limit, as bytes or a size such as `1g`; `-1` allows unlimited swap. Updated in place.",
+			},
+			"cpu_shares": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Relative CPU weight (default 1024). Updated in place.",
+			},
+			"cpuset_cpus": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "CPUs the container may run on, such as `0-3` or `0,2`. Updated in place.",
+			},
+			"pids_limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of processes; `-1` for no limit. Updated in place.",
+			},
+			"shm_size": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Size of `/dev/shm`, as bytes or a size such as `64m`. Changing this recreates the container.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ulimits": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Resource limits for container processes. Changing this recreates the container.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The limit name, such as `nofile` or `nproc`.",
+						},
+						"soft": schema.Int64Attribute{
+							Required:            true,
+							MarkdownDescription: "The soft limit.",
+						},
+						"hard": schema.Int64Attribute{
+							Required:            true,
+							MarkdownDescription: "The hard limit.",
+						},
+					},
+				},
+			},
+			"log_driver": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Logging driver, such as `json-file`, `local`, `syslog`, `gelf` or `loki`. Changing this recreates the container.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"log_opts": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Options for the logging driver, such as `max-size` and `max-file` for `json-file`. Changing this recreates the container.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -147,6 +371,90 @@ func (r *ContainerResource) Configure(_ context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *ContainerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ContainerResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.validateEnv(ctx, &resp.Diagnostics)
+	validateTriggerAction(config.TriggerAction, &resp.Diagnostics)
+	config.validateRuntimeOptions(ctx, &resp.Diagnostics)
+	config.validateSecurityOptions(ctx, &resp.Diagnostics)
+	config.validateResourceLimits(ctx, &resp.Diagnostics)
+	config.validateLogConfig(ctx, &resp.Diagnostics)
+
+	if !config.Upload.IsNull() && !config.Upload.IsUnknown() {
+		var uploads []ContainerUploadModel
+		resp.Diagnostics.Append(config.Upload.ElementsAs(ctx, &uploads, false)...)
+		for i, u := range uploads {
+			validateUpload(u, i, &resp.Diagnostics)
+		}
+	}
+}
+
+// UpgradeState upgrades state written by earlier versions of the resource.
+func (r *ContainerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored env as a list of KEY=VALUE strings.
+		0: {StateUpgrader: upgradeContainerStateV0},
+		// Version 1 stored memory as a number of bytes.
+		1: {StateUpgrader: upgradeContainerStateV1},
+	}
+}
+
+// ModifyPlan computes the content hash of each upload so that changed
+// files, including local source files, show up in the plan.
+func (r *ContainerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var uploadList types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("upload"), &uploadList)...)
+	if resp.Diagnostics.HasError() || uploadList.IsNull() || uploadList.IsUnknown() {
+		return
+	}
+
+	var uploads []ContainerUploadModel
+	resp.Diagnostics.Append(uploadList.ElementsAs(ctx, &uploads, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i := range uploads {
+		u := &uploads[i]
+		if u.Content.IsUnknown() || u.ContentBase64.IsUnknown() || u.Source.IsUnknown() {
+			u.ContentHash = types.StringUnknown()
+			continue
+		}
+
+		content, err := u.readContent()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("upload").AtListIndex(i),
+				"Error reading upload content",
+				"Could not read upload content: "+err.Error(),
+			)
+			return
+		}
+		u.ContentHash = types.StringValue(uploadContentHash(content))
+	}
+
+	uploadList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: containerUploadAttrTypes}, uploads)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("upload"), uploadList)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ContainerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ContainerResourceModel
@@ -163,19 +471,43 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		Image:   plan.Image.ValueString(),
 		Command: plan.Command.ValueString(),
 		Restart: plan.RestartPolicy.ValueString(),
-		Memory:  plan.Memory.ValueInt64(),
 		CPUs:    plan.CPUs.ValueFloat64(),
 	}
 
 	// Convert Terraform list/map types to Go types
-	var env []string
-	plan.Env.ElementsAs(ctx, &env, false)
-	containerReq.Env = env
+	resp.Diagnostics.Append(plan.applyEnv(ctx, containerReq)...)
 
 	var labels map[string]string
 	plan.Labels.ElementsAs(ctx, &labels, false)
 	containerReq.Labels = labels
 
+	resp.Diagnostics.Append(plan.applyRuntimeOptions(ctx, containerReq)...)
+	resp.Diagnostics.Append(plan.applySecurityOptions(ctx, containerReq)...)
+	resp.Diagnostics.Append(plan.applyResourceLimits(ctx, containerReq)...)
+	resp.Diagnostics.Append(plan.applyLogConfig(ctx, containerReq)...)
+
+	var uploads []ContainerUploadModel
+	resp.Diagnostics.Append(plan.Upload.ElementsAs(ctx, &uploads, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Files are uploaded before the container starts
+	if len(uploads) > 0 {
+		if err := hashUploads(uploads); err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading upload content",
+				"Could not read upload content: "+err.Error(),
+			)
+			return
+		}
+		plan.Upload, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: containerUploadAttrTypes}, uploads)
+		resp.Diagnostics.Append(diags...)
+
+		start := false
+		containerReq.Start = &start
+	}
+
 	createdContainer, err := r.client.CreateContainer(plan.EnvironmentID.ValueString(), containerReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -185,6 +517,21 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	if len(uploads) > 0 {
+		containerID := createdContainer.ID
+		createdContainer, err = r.uploadAndStart(plan.EnvironmentID.ValueString(), containerID, uploads)
+		if err != nil {
+			// Record the container so that it is tainted rather than leaked
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), containerID)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), plan.EnvironmentID)...)
+			resp.Diagnostics.AddError(
+				"Error starting container",
+				"Could not upload files to or start container: "+err.Error()+r.recentLogs(plan.EnvironmentID.ValueString(), containerID, plan.Name.ValueString()),
+			)
+			return
+		}
+	}
+
 	// Set state
 	plan.ID = types.StringValue(createdContainer.ID)
 	plan.State = types.StringValue(createdContainer.State)
@@ -194,6 +541,15 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	// The container is recorded above, so a failed start taints it
+	if reason := startupFailure(createdContainer); reason != "" {
+		resp.Diagnostics.AddError(
+			"Container failed to start",
+			fmt.Sprintf("Container %q was created but is %s.", plan.Name.ValueString(), reason)+
+				r.recentLogs(plan.EnvironmentID.ValueString(), createdContainer.ID, plan.Name.ValueString()),
+		)
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -222,6 +578,15 @@ func (r *ContainerResource) Read(ctx context.Context, req resource.ReadRequest,
 	state.State = types.StringValue(container.State)
 	state.Status = types.StringValue(container.Status)
 
+	resp.Diagnostics.Append(state.refreshEnv(ctx, container)...)
+	resp.Diagnostics.Append(state.refreshRuntimeOptions(ctx, container)...)
+	resp.Diagnostics.Append(state.refreshSecurityOptions(ctx, container)...)
+	resp.Diagnostics.Append(state.refreshResourceLimits(ctx, container)...)
+	resp.Diagnostics.Append(state.refreshLogConfig(ctx, container)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Trace(ctx, "Read container", map[string]any{"id": container.ID})
 
 	diags = resp.State.Set(ctx, state)
@@ -230,39 +595,121 @@ func (r *ContainerResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ContainerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ContainerResourceModel
+	var plan, state ContainerResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Update the container
-	containerReq := &client.Container{
-		ID:      plan.ID.ValueString(),
-		Name:    plan.Name.ValueString(),
-		Image:   plan.Image.ValueString(),
-		Command: plan.Command.ValueString(),
-		Restart: plan.RestartPolicy.ValueString(),
-		Memory:  plan.Memory.ValueInt64(),
-		CPUs:    plan.CPUs.ValueFloat64(),
+	containerReq, diags := plan.updateRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.updateRequest(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Convert Terraform list/map types to Go types
-	var env []string
-	plan.Env.ElementsAs(ctx, &env, false)
-	containerReq.Env = env
+	// Update the container only when a setting sent to Dockhand changed.
+	// Destroy options such as force and remove_volumes are only recorded.
+	var updatedContainer *client.Container
+	var recreated bool
+	var err error
+	if reflect.DeepEqual(containerReq, current) {
+		updatedContainer, err = r.client.GetContainer(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading container",
+				"Could not read container: "+err.Error(),
+			)
+			return
+		}
+	} else {
+		// Remember the container, to tell whether the update recreated it
+		before := &client.Container{ID: plan.ID.ValueString()}
+		if len(plan.Upload.Elements()) > 0 {
+			before, err = r.client.GetContainer(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading container",
+					"Could not read container before updating it: "+err.Error(),
+				)
+				return
+			}
+		}
+
+		updatedContainer, err = r.client.UpdateContainer(plan.EnvironmentID.ValueString(), plan.ID.ValueString(), containerReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating container",
+				"Could not update container: "+err.Error(),
+			)
+			return
+		}
+		recreated = containerRecreated(before, updatedContainer)
+	}
 
-	updatedContainer, err := r.client.UpdateContainer(plan.EnvironmentID.ValueString(), plan.ID.ValueString(), containerReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating container",
-			"Could not update container: "+err.Error(),
-		)
+	// Re-upload files whose content, permissions or owner changed, or every
+	// file when the update recreated the container and its filesystem
+	var planUploads, stateUploads []ContainerUploadModel
+	resp.Diagnostics.Append(plan.Upload.ElementsAs(ctx, &planUploads, false)...)
+	resp.Diagnostics.Append(state.Upload.ElementsAs(ctx, &stateUploads, false)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if len(planUploads) > 0 {
+		if err := hashUploads(planUploads); err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading upload content",
+				"Could not read upload content: "+err.Error(),
+			)
+			return
+		}
+		plan.Upload, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: containerUploadAttrTypes}, planUploads)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	changed := changedUploads(planUploads, stateUploads)
+	if recreated {
+		changed = planUploads
+	}
+	if len(changed) > 0 {
+		containerID := plan.ID.ValueString()
+		if updatedContainer.ID != "" {
+			containerID = updatedContainer.ID
+		}
+		if err := r.upload(plan.EnvironmentID.ValueString(), containerID, changed); err != nil {
+			resp.Diagnostics.AddError(
+				"Error uploading files",
+				"Could not upload files to container: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Restart the container when its triggers changed
+	if triggersChanged(state.Triggers, plan.Triggers, plan.TriggerAction) {
+		if err := r.client.RestartContainer(plan.EnvironmentID.ValueString(), plan.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error restarting container",
+				"Could not restart container after triggers changed: "+err.Error(),
+			)
+			return
+		}
+
+		updatedContainer, err = r.client.GetContainer(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading container",
+				"Could not read container after restart: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// Update state
 	plan.State = types.StringValue(updatedContainer.State)
 	plan.Status = types.StringValue(updatedContainer.Status)
@@ -283,8 +730,34 @@ func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	environmentID, containerID := state.EnvironmentID.ValueString(), state.ID.ValueString()
+
+	// Stop the container gracefully first, so it can finish in-flight work
+	if state.State.ValueString() == "running" || state.State.ValueString() == "paused" {
+		opts := &client.StopOptions{Signal: state.StopSignal.ValueString()}
+		if !state.StopTimeout.IsNull() {
+			timeout := int(state.StopTimeout.ValueInt64())
+			opts.Timeout = &timeout
+		}
+
+		if err := r.client.StopContainer(environmentID, containerID, opts); err != nil {
+			if !state.Force.ValueBool() {
+				resp.Diagnostics.AddError(
+					"Error stopping container",
+					"Could not stop container before deleting it: "+err.Error()+"\n\nSet force to remove it anyway.",
+				)
+				return
+			}
+
+			tflog.Warn(ctx, "Could not stop container, forcing removal", map[string]any{"id": containerID, "error": err.Error()})
+		}
+	}
+
 	// Delete the container
-	err := r.client.DeleteContainer(state.EnvironmentID.ValueString(), state.ID.ValueString())
+	err := r.client.DeleteContainer(environmentID, containerID, &client.DeleteContainerOptions{
+		Force:         state.Force.ValueBool(),
+		RemoveVolumes: state.RemoveVolumes.ValueBool(),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting container",
@@ -295,3 +768,126 @@ func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	tflog.Trace(ctx, "Deleted container", map[string]any{"id": state.ID.ValueString()})
 }
+
+// containerRecreated reports whether after is a new container replacing
+// before, rather than the same container updated in place.
+func containerRecreated(before, after *client.Container) bool {
+	return after.ID != "" && (after.ID != before.ID || after.Created != before.Created)
+}
+
+// updateRequest returns the container settings sent to Dockhand on update.
+func (m ContainerResourceModel) updateRequest(ctx context.Context) (*client.Container, diag.Diagnostics) {
+	c := &client.Container{
+		ID:      m.ID.ValueString(),
+		Name:    m.Name.ValueString(),
+		Image:   m.Image.ValueString(),
+		Command: m.Command.ValueString(),
+		Restart: m.RestartPolicy.ValueString(),
+		CPUs:    m.CPUs.ValueFloat64(),
+	}
+
+	// Convert Terraform list/map types to Go types
+	diags := m.applyEnv(ctx, c)
+	diags.Append(m.applyRuntimeOptions(ctx, c)...)
+	diags.Append(m.applySecurityOptions(ctx, c)...)
+	diags.Append(m.applyResourceLimits(ctx, c)...)
+	diags.Append(m.applyLogConfig(ctx, c)...)
+
+	return c, diags
+}
+
+// upload copies files into the container.
+func (r *ContainerResource) upload(environmentID, containerID string, uploads []ContainerUploadModel) error {
+	files := make([]client.ArchiveFile, 0, len(uploads))
+	for _, u := range uploads {
+		f, err := u.archiveFile()
+		if err != nil {
+			return fmt.Errorf("%s: %w", u.File.ValueString(), err)
+		}
+		files = append(files, f)
+	}
+
+	archive, err := client.BuildArchive(files)
+	if err != nil {
+		return err
+	}
+
+	return r.client.UploadArchive(environmentID, containerID, "/", archive)
+}
+
+// uploadAndStart copies files into a created container, starts it and
+// returns its refreshed state.
+func (r *ContainerResource) uploadAndStart(environmentID, containerID string, uploads []ContainerUploadModel) (*client.Container, error) {
+	if err := r.upload(environmentID, containerID, uploads); err != nil {
+		return nil, err
+	}
+
+	if err := r.client.StartContainer(environmentID, containerID); err != nil {
+		return nil, err
+	}
+
+	return r.client.GetContainer(environmentID, containerID)
+}
+
+// changedUploads returns the planned uploads that differ from the upload
+// with the same file in state.
+func changedUploads(plan, state []ContainerUploadModel) []ContainerUploadModel {
+	previous := make(map[string]ContainerUploadModel, len(state))
+	for _, u := range state {
+		previous[u.File.ValueString()] = u
+	}
+
+	var changed []ContainerUploadModel
+	for _, u := range plan {
+		p, ok := previous[u.File.ValueString()]
+		if !ok || !p.ContentHash.Equal(u.ContentHash) || !p.Permissions.Equal(u.Permissions) || !p.Owner.Equal(u.Owner) {
+			changed = append(changed, u)
+		}
+	}
+
+	return changed
+}
+
+// recentLogs returns the last log lines of a container formatted for an
+// error diagnostic, or an empty string when its logs cannot be fetched.
+func (r *ContainerResource) recentLogs(environmentID, containerID, name string) string {
+	logs, err := r.client.GetContainerLogs(environmentID, containerID, &client.ContainerLogsOptions{
+		Tail:   containerFailureLogLines,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return formatLogTail(name, logs)
+}
+
+// startupFailure describes why a container that was just started is not
+// running: it exited with an error, is restarting or is unhealthy. It
+// returns an empty string otherwise, including for containers that exited
+// successfully. A health check that is still starting is not a failure.
+func startupFailure(c *client.Container) string {
+	status := strings.ToLower(c.Status)
+
+	switch {
+	case c.State == "restarting":
+		return "restarting (" + c.Status + ")"
+	case (c.State == "exited" || c.State == "dead") && !strings.HasPrefix(status, "exited (0)"):
+		return c.State + " (" + c.Status + ")"
+	case strings.Contains(status, "(unhealthy)"):
+		return "unhealthy (" + c.Status + ")"
+	default:
+		return ""
+	}
+}
+
+// formatLogTail formats log output for appending to a diagnostic detail.
+func formatLogTail(name, logs string) string {
+	lines := splitLogLines(logs)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\nLast %d log lines of container %q:\n%s", len(lines), name, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &ContainerStatsDataSource{}
+
+// NewContainerStatsDataSource is a helper function to simplify the provider implementation.
+func NewContainerStatsDataSource() datasource.DataSource {
+	return &ContainerStatsDataSource{}
+}
+
+// ContainerStatsDataSource is the data source implementation.
+type ContainerStatsDataSource struct {
+	client *client.Client
+}
+
+// ContainerStatsDataSourceModel describes the data source data model.
+type ContainerStatsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	ContainerID   types.String `tfsdk:"container_id"`
+	Containers    types.List   `tfsdk:"containers"`
+	Total         types.Object `tfsdk:"total"`
+}
+
+// containerStatsAttrTypes describes a container stats object.
+var containerStatsAttrTypes = map[string]attr.Type{
+	"id":                types.StringType,
+	"name":              types.StringType,
+	"cpu_percent":       types.Float64Type,
+	"memory_usage":      types.Int64Type,
+	"memory_limit":      types.Int64Type,
+	"memory_percent":    types.Float64Type,
+	"network_rx_bytes":  types.Int64Type,
+	"network_tx_bytes":  types.Int64Type,
+	"block_read_bytes":  types.Int64Type,
+	"block_write_bytes": types.Int64Type,
+	"pids":              types.Int64Type,
+}
+
+// Metadata returns the data source type name.
+func (d *ContainerStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_stats"
+}
+
+// Schema defines the schema for the data source.
+func (d *ContainerStatsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a resource usage snapshot of a container, or of every running container in a Dockhand environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The data source ID.",
+			},
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"container_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The container ID or name. When omitted, stats are fetched for every running container in the environment.",
+			},
+			"containers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Stats for each container.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: containerStatsSchemaAttributes(),
+				},
+			},
+			"total": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Stats summed across `containers`. `memory_percent` is the total memory usage as a percentage of the summed limits.",
+				Attributes:          containerStatsSchemaAttributes(),
+			},
+		},
+	}
+}
+
+// containerStatsSchemaAttributes returns the computed attributes of a stats object.
+func containerStatsSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The container ID.",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The container name.",
+		},
+		"cpu_percent": schema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "CPU usage as a percentage of one CPU.",
+		},
+		"memory_usage": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Memory usage in bytes.",
+		},
+		"memory_limit": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Memory limit in bytes.",
+		},
+		"memory_percent": schema.Float64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Memory usage as a percentage of the limit.",
+		},
+		"network_rx_bytes": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Bytes received over all networks.",
+		},
+		"network_tx_bytes": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Bytes sent over all networks.",
+		},
+		"block_read_bytes": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Bytes read from block devices.",
+		},
+		"block_write_bytes": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Bytes written to block devices.",
+		},
+		"pids": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Number of processes.",
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ContainerStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ContainerStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ContainerStatsDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := config.EnvironmentID.ValueString()
+
+	// Select the containers to sample
+	containerIDs := []string{config.ContainerID.ValueString()}
+	if config.ContainerID.IsNull() {
+		containers, err := d.client.ListContainers(environmentID, &client.ListFilter{State: "running"})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading containers",
+				"Could not read containers: "+err.Error(),
+			)
+			return
+		}
+
+		containerIDs = containerIDs[:0]
+		for _, c := range containers {
+			if c.State == "running" {
+				containerIDs = append(containerIDs, c.ID)
+			}
+		}
+	}
+
+	stats := make([]client.ContainerStats, 0, len(containerIDs))
+	for _, id := range containerIDs {
+		s, err := d.client.GetContainerStats(environmentID, id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading container stats",
+				fmt.Sprintf("Could not read stats of container %s: %s", id, err.Error()),
+			)
+			return
+		}
+		stats = append(stats, *s)
+	}
+
+	elems := make([]map[string]attr.Value, 0, len(stats))
+	for _, s := range stats {
+		elems = append(elems, flattenContainerStats(s))
+	}
+
+	config.Containers, diags = objectListValue(containerStatsAttrTypes, elems)
+	resp.Diagnostics.Append(diags...)
+
+	config.Total, diags = types.ObjectValue(containerStatsAttrTypes, flattenContainerStats(aggregateContainerStats(stats)))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ID = types.StringValue(environmentID)
+	if !config.ContainerID.IsNull() {
+		config.ID = types.StringValue(config.ContainerID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Read container stats data source", map[string]any{"containers": len(stats)})
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenContainerStats converts API stats into object attribute values.
+func flattenContainerStats(s client.ContainerStats) map[string]attr.Value {
+	return map[string]attr.Value{
+		"id":                types.StringValue(s.ID),
+		"name":              types.StringValue(s.Name),
+		"cpu_percent":       types.Float64Value(s.CPUPercent),
+		"memory_usage":      types.Int64Value(s.MemoryUsage),
+		"memory_limit":      types.Int64Value(s.MemoryLimit),
+		"memory_percent":    types.Float64Value(s.MemoryPercent),
+		"network_rx_bytes":  types.Int64Value(s.NetworkRxBytes),
+		"network_tx_bytes":  types.Int64Value(s.NetworkTxBytes),
+		"block_read_bytes":  types.Int64Value(s.BlockReadBytes),
+		"block_write_bytes": types.Int64Value(s.BlockWriteBytes),
+		"pids":              types.Int64Value(s.PIDs),
+	}
+}
+
+// aggregateContainerStats sums stats across containers. The result has no
+// ID or name, and its memory percentage is relative to the summed limits.
+func aggregateContainerStats(stats []client.ContainerStats) client.ContainerStats {
+	var total client.ContainerStats
+	for _, s := range stats {
+		total.CPUPercent += s.CPUPercent
+		total.MemoryUsage += s.MemoryUsage
+		total.MemoryLimit += s.MemoryLimit
+		total.NetworkRxBytes += s.NetworkRxBytes
+		total.NetworkTxBytes += s.NetworkTxBytes
+		total.BlockReadBytes += s.BlockReadBytes
+		total.BlockWriteBytes += s.BlockWriteBytes
+		total.PIDs += s.PIDs
+	}
+
+	if total.MemoryLimit > 0 {
+		total.MemoryPercent = float64(total.MemoryUsage) / float64(total.MemoryLimit) * 100
+	}
+
+	return total
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// defaultUploadPermissions is the file mode used when permissions is not set.
+const defaultUploadPermissions = "0644"
+
+// ContainerUploadModel describes a file uploaded into a container.
+type ContainerUploadModel struct {
+	File          types.String `tfsdk:"file"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Source        types.String `tfsdk:"source"`
+	Permissions   types.String `tfsdk:"permissions"`
+	Owner         types.String `tfsdk:"owner"`
+	ContentHash   types.String `tfsdk:"content_hash"`
+}
+
+// containerUploadAttrTypes describes an upload object.
+var containerUploadAttrTypes = map[string]attr.Type{
+	"file":           types.StringType,
+	"content":        types.StringType,
+	"content_base64": types.StringType,
+	"source":         types.StringType,
+	"permissions":    types.StringType,
+	"owner":          types.StringType,
+	"content_hash":   types.StringType,
+}
+
+// containerUploadAttribute returns the schema of the upload attribute.
+func containerUploadAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Files copied into the container before it starts. " +
+			"Files whose content, permissions or owner change are uploaded again into the running container, and every file is uploaded again when an update recreates the container.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"file": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Absolute path of the file inside the container.",
+				},
+				"content": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Literal file content. Exactly one of `content`, `content_base64` or `source` must be set.",
+				},
+				"content_base64": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Base64-encoded file content, for binary files.",
+				},
+				"source": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Path of a local file whose content is uploaded.",
+				},
+				"permissions": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Octal file mode. Defaults to `" + defaultUploadPermissions + "`.",
+				},
+				"owner": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "File owner as `user:group`, by name or numeric ID. Defaults to `0:0`.",
+				},
+				"content_hash": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "SHA-256 of the uploaded content.",
+				},
+			},
+		},
+	}
+}
+
+// validateUpload checks the known values of an upload configured at index i.
+func validateUpload(u ContainerUploadModel, i int, diags *diag.Diagnostics) {
+	at := path.Root("upload").AtListIndex(i)
+
+	if !u.File.IsUnknown() {
+		file := u.File.ValueString()
+		if !strings.HasPrefix(file, "/") || strings.HasSuffix(file, "/") {
+			diags.AddAttributeError(at.AtName("file"), "Invalid upload file",
+				fmt.Sprintf("file must be an absolute file path, got %q.", file))
+		}
+	}
+
+	if u.Content.IsUnknown() || u.ContentBase64.IsUnknown() || u.Source.IsUnknown() {
+		return
+	}
+
+	set := 0
+	for _, v := range []types.String{u.Content, u.ContentBase64, u.Source} {
+		if !v.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		diags.AddAttributeError(at, "Invalid upload source",
+			"Exactly one of content, content_base64 or source must be set.")
+	}
+
+	if !u.ContentBase64.IsNull() {
+		if _, err := base64.StdEncoding.DecodeString(u.ContentBase64.ValueString()); err != nil {
+			diags.AddAttributeError(at.AtName("content_base64"), "Invalid upload content", "content_base64 is not valid base64: "+err.Error())
+		}
+	}
+
+	if !u.Permissions.IsNull() && !u.Permissions.IsUnknown() {
+		if _, err := parseFileMode(u.Permissions.ValueString()); err != nil {
+			diags.AddAttributeError(at.AtName("permissions"), "Invalid upload permissions", err.Error())
+		}
+	}
+
+	if !u.Owner.IsNull() && !u.Owner.IsUnknown() {
+		if _, err := parseOwner(u.Owner.ValueString()); err != nil {
+			diags.AddAttributeError(at.AtName("owner"), "Invalid upload owner", err.Error())
+		}
+	}
+}
+
+// readContent returns the bytes to upload.
+func (u ContainerUploadModel) readContent() ([]byte, error) {
+	switch {
+	case !u.ContentBase64.IsNull():
+		return base64.StdEncoding.DecodeString(u.ContentBase64.ValueString())
+	case !u.Source.IsNull():
+		return os.ReadFile(u.Source.ValueString())
+	default:
+		return []byte(u.Content.ValueString()), nil
+	}
+}
+
+// archiveFile converts the upload into an archive entry.
+func (u ContainerUploadModel) archiveFile() (client.ArchiveFile, error) {
+	content, err := u.readContent()
+	if err != nil {
+		return client.ArchiveFile{}, err
+	}
+
+	permissions := defaultUploadPermissions
+	if !u.Permissions.IsNull() {
+		permissions = u.Permissions.ValueString()
+	}
+	mode, err := parseFileMode(permissions)
+	if err != nil {
+		return client.ArchiveFile{}, err
+	}
+
+	f := client.ArchiveFile{
+		Path:    u.File.ValueString(),
+		Content: content,
+		Mode:    mode,
+	}
+
+	if !u.Owner.IsNull() {
+		owner, err := parseOwner(u.Owner.ValueString())
+		if err != nil {
+			return client.ArchiveFile{}, err
+		}
+		f.UID, f.GID, f.Uname, f.Gname = owner.UID, owner.GID, owner.Uname, owner.Gname
+	}
+
+	return f, nil
+}
+
+// hashUploads sets the content hash of every upload, resolving hashes that
+// were unknown at plan time.
+func hashUploads(uploads []ContainerUploadModel) error {
+	for i := range uploads {
+		content, err := uploads[i].readContent()
+		if err != nil {
+			return fmt.Errorf("%s: %w", uploads[i].File.ValueString(), err)
+		}
+		uploads[i].ContentHash = types.StringValue(uploadContentHash(content))
+	}
+
+	return nil
+}
+
+// uploadContentHash returns the hex SHA-256 of content.
+func uploadContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseFileMode parses an octal file mode such as "0644".
+func parseFileMode(s string) (int64, error) {
+	mode, err := strconv.ParseInt(s, 8, 64)
+	if err != nil || mode < 0 || mode > 0o7777 {
+		return 0, fmt.Errorf("permissions must be an octal file mode such as %q, got %q", defaultUploadPermissions, s)
+	}
+
+	return mode, nil
+}
+
+// parseOwner parses "user:group", where each part is a name or numeric ID.
+func parseOwner(s string) (client.ArchiveFile, error) {
+	var owner client.ArchiveFile
+
+	user, group, ok := strings.Cut(s, ":")
+	if !ok || user == "" || group == "" {
+		return owner, fmt.Errorf("owner must be in user:group form, got %q", s)
+	}
+
+	if uid, err := strconv.Atoi(user); err == nil {
+		owner.UID = uid
+	} else {
+		owner.Uname = user
+	}
+
+	if gid, err := strconv.Atoi(group); err == nil {
+		owner.GID = gid
+	} else {
+		owner.Gname = group
+	}
+
+	return owner, nil
+}
@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
@@ -28,17 +30,31 @@ type ContainersDataSource struct {
 // ContainersDataSourceModel describes the data source data model.
 type ContainersDataSourceModel struct {
 	EnvironmentID types.String `tfsdk:"environment_id"`
+	Filter        types.Object `tfsdk:"filter"`
 	Containers    types.List   `tfsdk:"containers"`
 	ID            types.String `tfsdk:"id"`
 }
 
+// ContainersFilterModel describes the filter attribute of the data source.
+type ContainersFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+	State     types.String `tfsdk:"state"`
+	Image     types.String `tfsdk:"image"`
+	Network   types.String `tfsdk:"network"`
+}
+
 // ContainerData describes a container in the data source
 type ContainerData struct {
-	ID     types.String `tfsdk:"id"`
-	Name   types.String `tfsdk:"name"`
-	Image  types.String `tfsdk:"image"`
-	State  types.String `tfsdk:"state"`
-	Status types.String `tfsdk:"status"`
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Image    types.String `tfsdk:"image"`
+	State    types.String `tfsdk:"state"`
+	Status   types.String `tfsdk:"status"`
+	Labels   types.Map    `tfsdk:"labels"`
+	Ports    types.List   `tfsdk:"ports"`
+	Networks types.List   `tfsdk:"networks"`
+	Created  types.String `tfsdk:"created"`
 }
 
 // Metadata returns the data source type name.
@@ -59,6 +75,20 @@ func (d *ContainersDataSource) Schema(ctx context.Context, _ datasource.SchemaRe
 				Computed:            true,
 				MarkdownDescription: "The data source ID.",
 			},
+			"filter": listFilterAttribute(map[string]schema.Attribute{
+				"state": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include containers in this state (e.g. running, exited).",
+				},
+				"image": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include containers created from this image reference.",
+				},
+				"network": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include containers attached to this network.",
+				},
+			}),
 			"containers": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "List of containers.",
@@ -84,6 +114,27 @@ func (d *ContainersDataSource) Schema(ctx context.Context, _ datasource.SchemaRe
 							Computed:            true,
 							MarkdownDescription: "The status.",
 						},
+						"labels": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The labels.",
+						},
+						"ports": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Port mappings.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: containerPortSchemaAttributes(),
+							},
+						},
+						"networks": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The networks the container is attached to.",
+						},
+						"created": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the container was created.",
+						},
 					},
 				},
 			},
@@ -122,8 +173,23 @@ func (d *ContainersDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	// Get containers
-	containers, err := d.client.ListContainers(config.EnvironmentID.ValueString())
+	var filterModel ContainersFilterModel
+	if !config.Filter.IsNull() {
+		resp.Diagnostics.Append(config.Filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{})...)
+	}
+
+	filter := newListFilter(ctx, filterModel.NameRegex, filterModel.Labels, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get containers, letting the API narrow the results where it can
+	containers, err := d.client.ListContainers(config.EnvironmentID.ValueString(), &client.ListFilter{
+		Labels:  filter.labels,
+		State:   filterModel.State.ValueString(),
+		Image:   filterModel.Image.ValueString(),
+		Network: filterModel.Network.ValueString(),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading containers",
@@ -133,24 +199,53 @@ func (d *ContainersDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	// Convert to Terraform types
-	var containersList []ContainerData
+	containersList := []ContainerData{}
 	for _, c := range containers {
-		containersList = append(containersList, ContainerData{
-			ID:     types.StringValue(c.ID),
-			Name:   types.StringValue(c.Name),
-			Image:  types.StringValue(c.Image),
-			State:  types.StringValue(c.State),
-			Status: types.StringValue(c.Status),
-		})
+		if !filter.matchName(c.Name) || !filter.matchLabels(c.Labels) {
+			continue
+		}
+		if state := filterModel.State.ValueString(); state != "" && c.State != state {
+			continue
+		}
+		if image := filterModel.Image.ValueString(); image != "" && canonicalImageReference(c.Image) != canonicalImageReference(image) {
+			continue
+		}
+		if network := filterModel.Network.ValueString(); network != "" && !slices.Contains(c.Networks, network) {
+			continue
+		}
+
+		item := ContainerData{
+			ID:      types.StringValue(c.ID),
+			Name:    types.StringValue(c.Name),
+			Image:   types.StringValue(c.Image),
+			State:   types.StringValue(c.State),
+			Status:  types.StringValue(c.Status),
+			Created: types.StringValue(c.Created),
+		}
+
+		item.Labels, diags = types.MapValueFrom(ctx, types.StringType, c.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		item.Ports, diags = flattenContainerPorts(c.Ports)
+		resp.Diagnostics.Append(diags...)
+
+		item.Networks, diags = types.ListValueFrom(ctx, types.StringType, c.Networks)
+		resp.Diagnostics.Append(diags...)
+
+		containersList = append(containersList, item)
 	}
 
 	containersValue, diags := types.ListValueFrom(ctx, types.ObjectType{
 		AttrTypes: map[string]attr.Type{
-			"id":     types.StringType,
-			"name":   types.StringType,
-			"image":  types.StringType,
-			"state":  types.StringType,
-			"status": types.StringType,
+			"id":       types.StringType,
+			"name":     types.StringType,
+			"image":    types.StringType,
+			"state":    types.StringType,
+			"status":   types.StringType,
+			"labels":   types.MapType{ElemType: types.StringType},
+			"ports":    types.ListType{ElemType: types.ObjectType{AttrTypes: containerPortAttrTypes}},
+			"networks": types.ListType{ElemType: types.StringType},
+			"created":  types.StringType,
 		},
 	}, containersList)
 	resp.Diagnostics.Append(diags...)
@@ -158,6 +253,7 @@ func (d *ContainersDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	// Set data
 	state := ContainersDataSourceModel{
 		EnvironmentID: config.EnvironmentID,
+		Filter:        config.Filter,
 		Containers:    containersValue,
 		ID:            types.StringValue(config.EnvironmentID.ValueString()),
 	}
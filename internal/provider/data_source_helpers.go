@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateLookupKeys checks that exactly one of id or name is configured on a
+// singular data source, and that it is not empty.
+func validateLookupKeys(id, name types.String, kind string, diags *diag.Diagnostics) {
+	keys := []struct {
+		name  string
+		value types.String
+	}{{"id", id}, {"name", name}}
+
+	for _, key := range keys {
+		if !key.value.IsNull() && !key.value.IsUnknown() && key.value.ValueString() == "" {
+			diags.AddAttributeError(
+				path.Root(key.name),
+				"Invalid "+kind+" lookup",
+				fmt.Sprintf("%s must not be empty; set it to look up a %s, or omit it.", key.name, kind),
+			)
+		}
+	}
+
+	if id.IsNull() == name.IsNull() {
+		diags.AddAttributeError(
+			path.Root("id"),
+			"Invalid "+kind+" lookup",
+			fmt.Sprintf("Exactly one of id or name must be set to look up a %s.", kind),
+		)
+	}
+}
+
+// lookupByName returns the single item whose name equals name. It adds an
+// attribute error and returns nil when there are zero or several matches.
+func lookupByName[T any](items []T, name string, nameOf func(T) string, kind string, diags *diag.Diagnostics) *T {
+	var matches []*T
+	for i := range items {
+		if nameOf(items[i]) == name {
+			matches = append(matches, &items[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		diags.AddAttributeError(
+			path.Root("name"),
+			fmt.Sprintf("No %s found", kind),
+			fmt.Sprintf("No %s named %q was found.", kind, name),
+		)
+		return nil
+	case 1:
+		return matches[0]
+	default:
+		diags.AddAttributeError(
+			path.Root("name"),
+			fmt.Sprintf("Multiple %ss found", kind),
+			fmt.Sprintf("Found %d %ss named %q; look the %s up by id instead.", len(matches), kind, name, kind),
+		)
+		return nil
+	}
+}
+
+// objectListValue builds a list of objects with the given attribute types.
+func objectListValue(attrTypes map[string]attr.Type, elems []map[string]attr.Value) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	objects := make([]attr.Value, 0, len(elems))
+	for _, e := range elems {
+		obj, d := types.ObjectValue(attrTypes, e)
+		diags.Append(d...)
+		objects = append(objects, obj)
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: attrTypes}, objects)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// listFilterAttribute returns the optional filter attribute shared by list
+// data sources. Every filter supports name_regex and labels; extra adds
+// filters specific to the data source.
+func listFilterAttribute(extra map[string]schema.Attribute) schema.SingleNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		"name_regex": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Only include items whose name matches this regular expression.",
+		},
+		"labels": schema.MapAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Only include items that carry all of these labels with the given values.",
+		},
+	}
+	for name, attribute := range extra {
+		attributes[name] = attribute
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Filters applied to the results. Filters supported by the Dockhand API are sent with the request; all filters are also applied by the provider.",
+		Attributes:          attributes,
+	}
+}
+
+// listFilter holds the name and label filters common to list data sources.
+type listFilter struct {
+	nameRegex *regexp.Regexp
+	labels    map[string]string
+}
+
+// newListFilter builds a listFilter from the name_regex and labels filter
+// attributes, adding an error when the regular expression is invalid.
+func newListFilter(ctx context.Context, nameRegex types.String, labels types.Map, diags *diag.Diagnostics) listFilter {
+	var f listFilter
+
+	if !nameRegex.IsNull() && nameRegex.ValueString() != "" {
+		re, err := regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("filter").AtName("name_regex"),
+				"Invalid name_regex",
+				"Could not compile regular expression: "+err.Error(),
+			)
+		}
+		f.nameRegex = re
+	}
+
+	if !labels.IsNull() {
+		diags.Append(labels.ElementsAs(ctx, &f.labels, false)...)
+	}
+
+	return f
+}
+
+// matchName reports whether any of names matches the name regex.
+func (f listFilter) matchName(names ...string) bool {
+	if f.nameRegex == nil {
+		return true
+	}
+
+	for _, name := range names {
+		if f.nameRegex.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchLabels reports whether labels contains every filtered label.
+func (f listFilter) matchLabels(labels map[string]string) bool {
+	for k, v := range f.labels {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+
+	return true
+}
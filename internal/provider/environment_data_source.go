@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &EnvironmentDataSource{}
+
+// NewEnvironmentDataSource is a helper function to simplify the provider implementation.
+func NewEnvironmentDataSource() datasource.DataSource {
+	return &EnvironmentDataSource{}
+}
+
+// EnvironmentDataSource is the data source implementation.
+type EnvironmentDataSource struct {
+	client *client.Client
+}
+
+// EnvironmentDataSourceModel describes the data source data model.
+type EnvironmentDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Host       types.String `tfsdk:"host"`
+	Port       types.Int64  `tfsdk:"port"`
+	Labels     types.Map    `tfsdk:"labels"`
+	Active     types.Bool   `tfsdk:"active"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	UpdatedAt  types.String `tfsdk:"updated_at"`
+	DockerInfo types.Object `tfsdk:"docker_info"`
+}
+
+// dockerInfoAttrTypes describes Docker daemon information.
+var dockerInfoAttrTypes = map[string]attr.Type{
+	"version":            types.StringType,
+	"api_version":        types.StringType,
+	"os":                 types.StringType,
+	"architecture":       types.StringType,
+	"containers":         types.Int64Type,
+	"containers_running": types.Int64Type,
+	"containers_paused":  types.Int64Type,
+	"containers_stopped": types.Int64Type,
+	"images":             types.Int64Type,
+}
+
+// Metadata returns the data source type name.
+func (d *EnvironmentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+// Schema defines the schema for the data source.
+func (d *EnvironmentDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single environment in Dockhand by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The environment ID. Exactly one of `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The environment name. Exactly one of `id` or `name` must be set.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of environment.",
+			},
+			"host": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The host address for remote environments.",
+			},
+			"port": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The port for remote environments.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels for the environment.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the environment is currently active.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the environment was created.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the environment was last updated.",
+			},
+			"docker_info": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Docker daemon information.",
+				Attributes: map[string]schema.Attribute{
+					"version": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Docker version.",
+					},
+					"api_version": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Docker API version.",
+					},
+					"os": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Operating system.",
+					},
+					"architecture": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "System architecture.",
+					},
+					"containers": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Total number of containers.",
+					},
+					"containers_running": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of running containers.",
+					},
+					"containers_paused": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of paused containers.",
+					},
+					"containers_stopped": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of stopped containers.",
+					},
+					"images": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of images.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *EnvironmentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *EnvironmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config EnvironmentDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	validateLookupKeys(config.ID, config.Name, "environment", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var env *client.Environment
+	if !config.ID.IsNull() {
+		e, err := d.client.GetEnvironment(config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading environment",
+				"Could not read environment: "+err.Error(),
+			)
+			return
+		}
+		env = e
+	} else {
+		envs, err := d.client.ListEnvironments(&client.ListFilter{Name: config.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading environments",
+				"Could not read environments: "+err.Error(),
+			)
+			return
+		}
+
+		env = lookupByName(envs, config.Name.ValueString(), func(e client.Environment) string { return e.Name }, "environment", &resp.Diagnostics)
+		if env == nil {
+			return
+		}
+	}
+
+	state := EnvironmentDataSourceModel{
+		ID:        types.StringValue(env.ID),
+		Name:      types.StringValue(env.Name),
+		Type:      types.StringValue(env.Type),
+		Host:      types.StringValue(env.Host),
+		Port:      types.Int64Value(int64(env.Port)),
+		Active:    types.BoolValue(env.Active),
+		CreatedAt: types.StringValue(env.CreatedAt),
+		UpdatedAt: types.StringValue(env.UpdatedAt),
+	}
+
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, env.Labels)
+	resp.Diagnostics.Append(diags...)
+
+	state.DockerInfo, diags = flattenDockerInfo(env.DockerInfo)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read environment data source", map[string]any{"id": env.ID})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenDockerInfo converts Docker daemon information into an object.
+func flattenDockerInfo(info *client.DockerInfo) (types.Object, diag.Diagnostics) {
+	if info == nil {
+		return types.ObjectNull(dockerInfoAttrTypes), nil
+	}
+
+	return types.ObjectValue(dockerInfoAttrTypes, map[string]attr.Value{
+		"version":            types.StringValue(info.Version),
+		"api_version":        types.StringValue(info.APIVersion),
+		"os":                 types.StringValue(info.OS),
+		"architecture":       types.StringValue(info.Architecture),
+		"containers":         types.Int64Value(int64(info.Containers)),
+		"containers_running": types.Int64Value(int64(info.ContainersRunning)),
+		"containers_paused":  types.Int64Value(int64(info.ContainersPaused)),
+		"containers_stopped": types.Int64Value(int64(info.ContainersStopped)),
+		"images":             types.Int64Value(int64(info.Images)),
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
@@ -24,17 +25,30 @@ type EnvironmentsDataSource struct {
 
 // EnvironmentsDataSourceModel describes the data source data model.
 type EnvironmentsDataSourceModel struct {
+	Filter       types.Object `tfsdk:"filter"`
 	Environments types.List   `tfsdk:"environments"`
 	ID           types.String `tfsdk:"id"`
 }
 
+// EnvironmentsFilterModel describes the filter attribute of the data source.
+type EnvironmentsFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+	Type      types.String `tfsdk:"type"`
+	Active    types.Bool   `tfsdk:"active"`
+}
+
 // EnvironmentData describes an environment in the data source
 type EnvironmentData struct {
-	ID     types.String `tfsdk:"id"`
-	Name   types.String `tfsdk:"name"`
-	Type   types.String `tfsdk:"type"`
-	Host   types.String `tfsdk:"host"`
-	Active types.Bool   `tfsdk:"active"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Host      types.String `tfsdk:"host"`
+	Port      types.Int64  `tfsdk:"port"`
+	Active    types.Bool   `tfsdk:"active"`
+	Labels    types.Map    `tfsdk:"labels"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
 }
 
 func (d *EnvironmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -48,15 +62,29 @@ func (d *EnvironmentsDataSource) Schema(ctx context.Context, _ datasource.Schema
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"filter": listFilterAttribute(map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include environments of this type (local, ssh, docker_socket, tcp).",
+				},
+				"active": schema.BoolAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include environments whose active flag matches.",
+				},
+			}),
 			"environments": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"id":     schema.StringAttribute{Computed: true},
-						"name":   schema.StringAttribute{Computed: true},
-						"type":   schema.StringAttribute{Computed: true},
-						"host":   schema.StringAttribute{Computed: true},
-						"active": schema.BoolAttribute{Computed: true},
+						"id":         schema.StringAttribute{Computed: true},
+						"name":       schema.StringAttribute{Computed: true},
+						"type":       schema.StringAttribute{Computed: true},
+						"host":       schema.StringAttribute{Computed: true},
+						"port":       schema.Int64Attribute{Computed: true},
+						"active":     schema.BoolAttribute{Computed: true},
+						"labels":     schema.MapAttribute{Computed: true, ElementType: types.StringType},
+						"created_at": schema.StringAttribute{Computed: true},
+						"updated_at": schema.StringAttribute{Computed: true},
 					},
 				},
 			},
@@ -93,7 +121,17 @@ func (d *EnvironmentsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	envs, err := d.client.ListEnvironments()
+	var filterModel EnvironmentsFilterModel
+	if !state.Filter.IsNull() {
+		resp.Diagnostics.Append(state.Filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{})...)
+	}
+
+	filter := newListFilter(ctx, filterModel.NameRegex, filterModel.Labels, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	envs, err := d.client.ListEnvironments(&client.ListFilter{Labels: filter.labels})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading environments",
@@ -102,29 +140,52 @@ func (d *EnvironmentsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	var envList []EnvironmentData
+	envList := []EnvironmentData{}
 	for _, e := range envs {
-		envList = append(envList, EnvironmentData{
-			ID:     types.StringValue(e.ID),
-			Name:   types.StringValue(e.Name),
-			Type:   types.StringValue(e.Type),
-			Host:   types.StringValue(e.Host),
-			Active: types.BoolValue(e.Active),
-		})
+		if !filter.matchName(e.Name) || !filter.matchLabels(e.Labels) {
+			continue
+		}
+		if envType := filterModel.Type.ValueString(); envType != "" && e.Type != envType {
+			continue
+		}
+		if !filterModel.Active.IsNull() && e.Active != filterModel.Active.ValueBool() {
+			continue
+		}
+
+		item := EnvironmentData{
+			ID:        types.StringValue(e.ID),
+			Name:      types.StringValue(e.Name),
+			Type:      types.StringValue(e.Type),
+			Host:      types.StringValue(e.Host),
+			Port:      types.Int64Value(int64(e.Port)),
+			Active:    types.BoolValue(e.Active),
+			CreatedAt: types.StringValue(e.CreatedAt),
+			UpdatedAt: types.StringValue(e.UpdatedAt),
+		}
+
+		item.Labels, diags = types.MapValueFrom(ctx, types.StringType, e.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		envList = append(envList, item)
 	}
 
 	environmentsValue, diags := types.ListValueFrom(ctx, types.ObjectType{
 		AttrTypes: map[string]attr.Type{
-			"id":     types.StringType,
-			"name":   types.StringType,
-			"type":   types.StringType,
-			"host":   types.StringType,
-			"active": types.BoolType,
+			"id":         types.StringType,
+			"name":       types.StringType,
+			"type":       types.StringType,
+			"host":       types.StringType,
+			"port":       types.Int64Type,
+			"active":     types.BoolType,
+			"labels":     types.MapType{ElemType: types.StringType},
+			"created_at": types.StringType,
+			"updated_at": types.StringType,
 		},
 	}, envList)
 	resp.Diagnostics.Append(diags...)
 
 	state = EnvironmentsDataSourceModel{
+		Filter:       state.Filter,
 		Environments: environmentsValue,
 		ID:           types.StringValue("dockhand_environments"),
 	}
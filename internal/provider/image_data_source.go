@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &ImageDataSource{}
+
+// NewImageDataSource is a helper function to simplify the provider implementation.
+func NewImageDataSource() datasource.DataSource {
+	return &ImageDataSource{}
+}
+
+// ImageDataSource is the data source implementation.
+type ImageDataSource struct {
+	client *client.Client
+}
+
+// ImageDataSourceModel describes the data source data model.
+type ImageDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Name          types.String `tfsdk:"name"`
+	RepoTags      types.List   `tfsdk:"repo_tags"`
+	RepoDigests   types.List   `tfsdk:"repo_digests"`
+	Size          types.Int64  `tfsdk:"size"`
+	Created       types.String `tfsdk:"created"`
+	Labels        types.Map    `tfsdk:"labels"`
+	Architecture  types.String `tfsdk:"architecture"`
+	OS            types.String `tfsdk:"os"`
+}
+
+// Metadata returns the data source type name.
+func (d *ImageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+// Schema defines the schema for the data source.
+func (d *ImageDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single image in a Dockhand environment by ID or reference.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The image ID. Exactly one of `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The image reference, as `repository:tag` or `repository@digest`. Exactly one of `id` or `name` must be set.",
+			},
+			"repo_tags": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Repository tags for the image.",
+			},
+			"repo_digests": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Repository digests for the image.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size of the image in bytes.",
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the image was created.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels on the image.",
+			},
+			"architecture": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Architecture of the image.",
+			},
+			"os": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Operating system of the image.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ImageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ImageDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	validateLookupKeys(config.ID, config.Name, "image", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := config.EnvironmentID.ValueString()
+
+	var image *client.Image
+	if !config.ID.IsNull() {
+		img, err := d.client.GetImage(environmentID, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading image",
+				"Could not read image: "+err.Error(),
+			)
+			return
+		}
+		image = img
+	} else {
+		images, err := d.client.ListImages(environmentID, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading images",
+				"Could not read images: "+err.Error(),
+			)
+			return
+		}
+
+		image = findImageByReference(images, config.Name.ValueString())
+		if image == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"No image found",
+				fmt.Sprintf("No image matching %q was found.", config.Name.ValueString()),
+			)
+			return
+		}
+	}
+
+	state := ImageDataSourceModel{
+		ID:            types.StringValue(image.ID),
+		EnvironmentID: config.EnvironmentID,
+		Name:          config.Name,
+		Size:          types.Int64Value(image.Size),
+		Created:       types.StringValue(image.Created),
+		Architecture:  types.StringValue(image.Architecture),
+		OS:            types.StringValue(image.OS),
+	}
+
+	// Default the name to the first tag when looking up by ID
+	if state.Name.IsNull() {
+		name := ""
+		if len(image.RepoTags) > 0 {
+			name = image.RepoTags[0]
+		}
+		state.Name = types.StringValue(name)
+	}
+
+	state.RepoTags, diags = types.ListValueFrom(ctx, types.StringType, image.RepoTags)
+	resp.Diagnostics.Append(diags...)
+
+	state.RepoDigests, diags = types.ListValueFrom(ctx, types.StringType, image.RepoDigests)
+	resp.Diagnostics.Append(diags...)
+
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, image.Labels)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read image data source", map[string]any{"id": image.ID})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -169,7 +169,7 @@ func (r *ImagePullResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Try to get the image to verify it exists
-	images, err := r.client.ListImages(state.EnvironmentID.ValueString())
+	images, err := r.client.ListImages(state.EnvironmentID.ValueString(), nil)
 	if err != nil {
 		// Image might not exist anymore, which is not necessarily an error
 		tflog.Trace(ctx, "Could not list images", map[string]any{"error": err.Error()})
@@ -3,9 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -30,6 +34,10 @@ type ImageResource struct {
 type ImageResourceModel struct {
 	ID            types.String `tfsdk:"id"`
 	EnvironmentID types.String `tfsdk:"environment_id"`
+	Name          types.String `tfsdk:"name"`
+	Pull          types.Bool   `tfsdk:"pull"`
+	Pulled        types.Bool   `tfsdk:"pulled"`
+	PulledID      types.String `tfsdk:"pulled_id"`
 	RepoTags      types.List   `tfsdk:"repo_tags"`
 	RepoDigests   types.List   `tfsdk:"repo_digests"`
 	Size          types.Int64  `tfsdk:"size"`
@@ -47,7 +55,7 @@ func (r *ImageResource) Metadata(_ context.Context, req resource.MetadataRequest
 // Schema defines the schema for the resource.
 func (r *ImageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Docker image in Dockhand. This is typically used for image metadata only. Use the image_pull resource for pulling/downloading images.",
+		MarkdownDescription: "Manages a Docker image in Dockhand, looked up by reference. The image is pulled when `pull` is set and it is missing from the environment; otherwise use the image_pull resource for pulling/downloading images.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -59,6 +67,34 @@ func (r *ImageResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 			"environment_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The environment ID where the image exists.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The image reference to look up, as `repository:tag`, `repository@digest` or an image ID (e.g., nginx:latest).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pull": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Pull the image when it is not present in the environment.",
+			},
+			"pulled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether this resource pulled the image. Only images pulled by the resource are deleted on destroy; images that were already present are left in place.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pulled_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the image this resource pulled, if any. It is not refreshed when `name` moves to another image, and is the only image deleted on destroy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"repo_tags": schema.ListAttribute{
 				Computed:            true,
@@ -116,7 +152,7 @@ func (r *ImageResource) Configure(_ context.Context, req resource.ConfigureReque
 }
 
 // Create creates the resource and sets the initial Terraform state.
-// For the image resource, this is a read-only operation.
+// The image is resolved by reference and pulled first if requested.
 func (r *ImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ImageResourceModel
 
@@ -126,25 +162,60 @@ func (r *ImageResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Get the image
-	image, err := r.client.GetImage(plan.EnvironmentID.ValueString(), plan.ID.ValueString())
+	environmentID := plan.EnvironmentID.ValueString()
+	reference := plan.Name.ValueString()
+
+	image, err := r.lookupImage(environmentID, reference)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading image",
-			"Could not read image: "+err.Error(),
+			"Could not list images: "+err.Error(),
 		)
 		return
 	}
 
-	// Set state
-	plan.ID = types.StringValue(image.ID)
-	plan.Size = types.Int64Value(image.Size)
-	plan.Created = types.StringValue(image.Created)
-	plan.Architecture = types.StringValue(image.Architecture)
-	plan.OS = types.StringValue(image.OS)
+	plan.Pulled = types.BoolValue(false)
+	plan.PulledID = types.StringNull()
+	if image == nil && plan.Pull.ValueBool() {
+		err = r.client.PullImage(environmentID, &client.ImagePullRequest{Image: reference})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error pulling image",
+				"Could not pull image: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Trace(ctx, "Pulled missing image", map[string]any{"image": reference})
+		plan.Pulled = types.BoolValue(true)
+
+		image, err = r.lookupImage(environmentID, reference)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading image",
+				"Could not list images: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if image == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Image not found",
+			fmt.Sprintf("No image matching %q exists in environment %s. Set pull = true to pull it automatically.", reference, environmentID),
+		)
+		return
+	}
 
-	repoTags, _ := types.ListValueFrom(ctx, types.StringType, image.RepoTags)
-	plan.RepoTags = repoTags
+	// Set state
+	if plan.Pulled.ValueBool() {
+		plan.PulledID = types.StringValue(image.ID)
+	}
+	resp.Diagnostics.Append(plan.setImage(ctx, image)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Trace(ctx, "Read image", map[string]any{"id": image.ID})
 
@@ -162,21 +233,32 @@ func (r *ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get the image
-	image, err := r.client.GetImage(state.EnvironmentID.ValueString(), state.ID.ValueString())
+	// Resolve the reference again, so that a tag moved to a newer image
+	// shows up as a new ID
+	image, err := r.lookupImage(state.EnvironmentID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading image",
-			"Could not read image: "+err.Error(),
+			"Could not list images: "+err.Error(),
 		)
 		return
 	}
 
+	if image == nil {
+		// Image no longer exists, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if image.ID != state.ID.ValueString() {
+		tflog.Debug(ctx, "Image reference resolves to a new image", map[string]any{"name": state.Name.ValueString(), "old_id": state.ID.ValueString(), "new_id": image.ID})
+	}
+
 	// Update state
-	state.Size = types.Int64Value(image.Size)
-	state.Created = types.StringValue(image.Created)
-	state.Architecture = types.StringValue(image.Architecture)
-	state.OS = types.StringValue(image.OS)
+	resp.Diagnostics.Append(state.setImage(ctx, image)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Trace(ctx, "Read image", map[string]any{"id": image.ID})
 
@@ -186,31 +268,50 @@ func (r *ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Images cannot be updated, so we just read the current state
+	// Images cannot be updated; only pull may change, which has no effect on
+	// an image that already exists, so we just refresh the metadata.
+	var plan ImageResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state ImageResourceModel
-	diags := req.State.Get(ctx, &state)
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Get the image
-	image, err := r.client.GetImage(state.EnvironmentID.ValueString(), state.ID.ValueString())
+	image, err := r.lookupImage(state.EnvironmentID.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading image",
-			"Could not read image: "+err.Error(),
+			"Could not list images: "+err.Error(),
+		)
+		return
+	}
+
+	if image == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Image not found",
+			fmt.Sprintf("No image matching %q exists in environment %s.", state.Name.ValueString(), state.EnvironmentID.ValueString()),
 		)
 		return
 	}
 
 	// Update state
-	state.Size = types.Int64Value(image.Size)
-	state.Created = types.StringValue(image.Created)
-	state.Architecture = types.StringValue(image.Architecture)
-	state.OS = types.StringValue(image.OS)
+	plan.Pulled = state.Pulled
+	plan.PulledID = state.PulledID
+	resp.Diagnostics.Append(plan.setImage(ctx, image)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	diags = resp.State.Set(ctx, state)
+	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -224,8 +325,15 @@ func (r *ImageResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Leave images the resource only looked up. The pulled image is deleted
+	// by its ID, since name may have moved to an image used elsewhere.
+	if state.PulledID.ValueString() == "" {
+		tflog.Trace(ctx, "Keeping image that was not pulled by the resource", map[string]any{"id": state.ID.ValueString()})
+		return
+	}
+
 	// Delete the image
-	err := r.client.DeleteImage(state.EnvironmentID.ValueString(), state.ID.ValueString())
+	err := r.client.DeleteImage(state.EnvironmentID.ValueString(), state.PulledID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting image",
@@ -234,5 +342,89 @@ func (r *ImageResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	tflog.Trace(ctx, "Deleted image", map[string]any{"id": state.ID.ValueString()})
+	tflog.Trace(ctx, "Deleted image", map[string]any{"id": state.PulledID.ValueString()})
+}
+
+// lookupImage resolves reference against the images in an environment,
+// returning nil when no image matches.
+func (r *ImageResource) lookupImage(environmentID, reference string) (*client.Image, error) {
+	images, err := r.client.ListImages(environmentID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return findImageByReference(images, reference), nil
+}
+
+// setImage copies the image metadata into the model.
+func (m *ImageResourceModel) setImage(ctx context.Context, image *client.Image) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(image.ID)
+	m.Size = types.Int64Value(image.Size)
+	m.Created = types.StringValue(image.Created)
+	m.Architecture = types.StringValue(image.Architecture)
+	m.OS = types.StringValue(image.OS)
+
+	repoTags, d := types.ListValueFrom(ctx, types.StringType, image.RepoTags)
+	diags.Append(d...)
+	m.RepoTags = repoTags
+
+	repoDigests, d := types.ListValueFrom(ctx, types.StringType, image.RepoDigests)
+	diags.Append(d...)
+	m.RepoDigests = repoDigests
+
+	labels, d := types.MapValueFrom(ctx, types.StringType, image.Labels)
+	diags.Append(d...)
+	m.Labels = labels
+
+	return diags
+}
+
+// findImageByReference returns the image matching reference by ID, tag or
+// digest. References are normalized so that "nginx", "nginx:latest" and
+// "docker.io/library/nginx:latest" all match the same tag.
+func findImageByReference(images []client.Image, reference string) *client.Image {
+	want := canonicalImageReference(reference)
+
+	for i := range images {
+		img := &images[i]
+
+		if img.ID == reference || strings.TrimPrefix(img.ID, "sha256:") == reference {
+			return img
+		}
+
+		for _, repoTag := range img.RepoTags {
+			if canonicalImageReference(repoTag) == want {
+				return img
+			}
+		}
+
+		for _, digest := range img.RepoDigests {
+			if normalizeImageName(digest) == want {
+				return img
+			}
+		}
+	}
+
+	return nil
+}
+
+// canonicalImageReference normalizes an image reference for comparison,
+// adding the implicit "latest" tag to references without a tag or digest.
+func canonicalImageReference(reference string) string {
+	if strings.Contains(reference, "@") {
+		return normalizeImageName(reference)
+	}
+
+	repository, tag := splitImageReference(reference)
+	return normalizeImageName(repository) + ":" + tag
+}
+
+// normalizeImageName strips the implicit Docker Hub registry and library
+// namespace from an image reference.
+func normalizeImageName(name string) string {
+	name = strings.TrimPrefix(name, "docker.io/")
+	name = strings.TrimPrefix(name, "index.docker.io/")
+	return strings.TrimPrefix(name, "library/")
 }
@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a resource.Resource
+var _ resource.Resource = &ImageTagResource{}
+var _ resource.ResourceWithValidateConfig = &ImageTagResource{}
+
+// NewImageTagResource is a helper function to simplify the provider implementation.
+func NewImageTagResource() resource.Resource {
+	return &ImageTagResource{}
+}
+
+// ImageTagResource is the resource implementation.
+type ImageTagResource struct {
+	client *client.Client
+}
+
+// ImageTagResourceModel describes the resource data model.
+type ImageTagResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	SourceImage   types.String `tfsdk:"source_image"`
+	Target        types.String `tfsdk:"target"`
+	Push          types.Bool   `tfsdk:"push"`
+	Registry      types.String `tfsdk:"registry"`
+	ImageID       types.String `tfsdk:"image_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *ImageTagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_tag"
+}
+
+// Schema defines the schema for the resource.
+func (r *ImageTagResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tags a Docker image in a Dockhand environment and optionally pushes the new tag to a registry.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The tag ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID where the image exists.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_image": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The image reference or ID to tag (e.g., nginx:latest).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The new image reference in `repository:tag` form (e.g., registry.company.com/nginx:1.25). The tag defaults to `latest` when omitted. Digest references cannot be used as a target.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"push": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Push the target reference to its registry after tagging.",
+			},
+			"registry": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The Dockhand registry whose stored credentials are used for the push. Defaults to the registry in the target reference.",
+			},
+			"image_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the tagged image.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ImageTagResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *ImageTagResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var target types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("target"), &target)...)
+	if resp.Diagnostics.HasError() || target.IsNull() || target.IsUnknown() {
+		return
+	}
+
+	if strings.Contains(target.ValueString(), "@") {
+		resp.Diagnostics.AddAttributeError(path.Root("target"), "Invalid target",
+			fmt.Sprintf("target must be a repository and tag, such as registry.company.com/nginx:1.25; digest references like %q cannot be created by tagging.", target.ValueString()))
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ImageTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ImageTagResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repository, tag := splitImageReference(plan.Target.ValueString())
+
+	// Tag the image
+	tagReq := &client.ImageTagRequest{
+		Source:     plan.SourceImage.ValueString(),
+		Repository: repository,
+		Tag:        tag,
+	}
+
+	err := r.client.TagImage(plan.EnvironmentID.ValueString(), tagReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error tagging image",
+			"Could not tag image: "+err.Error(),
+		)
+		return
+	}
+
+	// Push the new tag if requested
+	if plan.Push.ValueBool() {
+		if err := r.push(plan); err != nil {
+			msg := "Could not push image: " + err.Error()
+
+			// The tag is not recorded in state, so do not leave it behind
+			if untagErr := r.client.UntagImage(plan.EnvironmentID.ValueString(), plan.Target.ValueString()); untagErr != nil {
+				msg += fmt.Sprintf("\n\nThe tag %s could not be removed and must be removed manually: %s", plan.Target.ValueString(), untagErr.Error())
+			}
+
+			resp.Diagnostics.AddError("Error pushing image", msg)
+			return
+		}
+	}
+
+	imageID, err := r.findImageID(plan.EnvironmentID.ValueString(), plan.Target.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading image",
+			"Could not read tagged image: "+err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	plan.ID = types.StringValue(plan.Target.ValueString() + "@" + plan.EnvironmentID.ValueString())
+	plan.ImageID = types.StringValue(imageID)
+
+	tflog.Trace(ctx, "Tagged image", map[string]any{"id": plan.ID.ValueString(), "source": plan.SourceImage.ValueString()})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ImageTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ImageTagResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	imageID, err := r.findImageID(state.EnvironmentID.ValueString(), state.Target.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading image",
+			"Could not read tagged image: "+err.Error(),
+		)
+		return
+	}
+
+	if imageID == "" {
+		// Tag no longer exists, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ImageID = types.StringValue(imageID)
+
+	tflog.Trace(ctx, "Read image tag", map[string]any{"id": state.ID.ValueString()})
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state.
+func (r *ImageTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ImageTagResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state ImageTagResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only push and registry can change in place; push again when either
+	// newly enables pushing or redirects it to a different registry.
+	pushChanged := plan.Push.ValueBool() && (!state.Push.ValueBool() || plan.Registry.ValueString() != state.Registry.ValueString())
+	if pushChanged {
+		if err := r.push(plan); err != nil {
+			resp.Diagnostics.AddError(
+				"Error pushing image",
+				"Could not push image: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.ImageID = state.ImageID
+
+	tflog.Trace(ctx, "Updated image tag", map[string]any{"id": plan.ID.ValueString(), "pushed": pushChanged})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ImageTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ImageTagResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove only the tag; the source image and anything already pushed to
+	// the registry are left in place.
+	err := r.client.UntagImage(state.EnvironmentID.ValueString(), state.Target.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error removing image tag",
+			"Could not remove image tag: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Removed image tag", map[string]any{"id": state.ID.ValueString()})
+}
+
+// push pushes the target reference using the stored registry credentials.
+func (r *ImageTagResource) push(plan ImageTagResourceModel) error {
+	pushReq := &client.ImagePushRequest{
+		Image:    plan.Target.ValueString(),
+		Registry: plan.Registry.ValueString(),
+	}
+
+	return r.client.PushImage(plan.EnvironmentID.ValueString(), pushReq)
+}
+
+// findImageID returns the ID of the image carrying reference, or an empty
+// string when no image in the environment has that tag.
+func (r *ImageTagResource) findImageID(environmentID, reference string) (string, error) {
+	images, err := r.client.ListImages(environmentID, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if img := findImageByReference(images, reference); img != nil {
+		return img.ID, nil
+	}
+
+	return "", nil
+}
+
+// splitImageReference splits an image reference into repository and tag,
+// defaulting the tag to "latest". A colon that belongs to a registry host
+// port (e.g. registry:5000/app) is not treated as a tag separator, and a
+// digest (e.g. nginx@sha256:...) is dropped.
+func splitImageReference(reference string) (string, string) {
+	reference, _, _ = strings.Cut(reference, "@")
+
+	lastSlash := strings.LastIndex(reference, "/")
+	lastColon := strings.LastIndex(reference, ":")
+
+	if lastColon > lastSlash {
+		return reference[:lastColon], reference[lastColon+1:]
+	}
+
+	return reference, "latest"
+}
@@ -2,34 +2,210 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
 
+// Ensure the implementation defined in this package is a datasource.DataSource
 var _ datasource.DataSource = &ImagesDataSource{}
 
+// NewImagesDataSource is a helper function to simplify the provider implementation.
 func NewImagesDataSource() datasource.DataSource {
 	return &ImagesDataSource{}
 }
 
+// ImagesDataSource is the data source implementation.
 type ImagesDataSource struct {
 	client *client.Client
 }
 
+// ImagesDataSourceModel describes the data source data model.
+type ImagesDataSourceModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Filter        types.Object `tfsdk:"filter"`
+	Images        types.List   `tfsdk:"images"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// ImagesFilterModel describes the filter attribute of the data source.
+type ImagesFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+}
+
+// ImageData describes an image in the data source
+type ImageData struct {
+	ID          types.String `tfsdk:"id"`
+	RepoTags    types.List   `tfsdk:"repo_tags"`
+	RepoDigests types.List   `tfsdk:"repo_digests"`
+	Size        types.Int64  `tfsdk:"size"`
+	Created     types.String `tfsdk:"created"`
+	Labels      types.Map    `tfsdk:"labels"`
+}
+
+// Metadata returns the data source type name.
 func (d *ImagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_images"
 }
 
+// Schema defines the schema for the data source.
 func (d *ImagesDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Fetches a list of images in a Dockhand environment.",
+		MarkdownDescription: "Fetches a list of images in a Dockhand environment. The `name_regex` filter is matched against each repository tag.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The data source ID.",
+			},
+			"filter": listFilterAttribute(nil),
+			"images": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of images.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The image ID.",
+						},
+						"repo_tags": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Repository tags for the image.",
+						},
+						"repo_digests": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Repository digests for the image.",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the image in bytes.",
+						},
+						"created": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the image was created.",
+						},
+						"labels": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The labels.",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
+// Configure adds the provider configured client to the data source.
 func (d *ImagesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
 }
 
+// Read refreshes the Terraform state with the latest data.
 func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ImagesDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filterModel ImagesFilterModel
+	if !config.Filter.IsNull() {
+		resp.Diagnostics.Append(config.Filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{})...)
+	}
+
+	filter := newListFilter(ctx, filterModel.NameRegex, filterModel.Labels, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get images
+	images, err := d.client.ListImages(config.EnvironmentID.ValueString(), &client.ListFilter{Labels: filter.labels})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading images",
+			"Could not read images: "+err.Error(),
+		)
+		return
+	}
+
+	// Convert to Terraform types
+	imagesList := []ImageData{}
+	for _, img := range images {
+		if !filter.matchName(img.RepoTags...) || !filter.matchLabels(img.Labels) {
+			continue
+		}
+
+		item := ImageData{
+			ID:      types.StringValue(img.ID),
+			Size:    types.Int64Value(img.Size),
+			Created: types.StringValue(img.Created),
+		}
+
+		item.RepoTags, diags = types.ListValueFrom(ctx, types.StringType, img.RepoTags)
+		resp.Diagnostics.Append(diags...)
+
+		item.RepoDigests, diags = types.ListValueFrom(ctx, types.StringType, img.RepoDigests)
+		resp.Diagnostics.Append(diags...)
+
+		item.Labels, diags = types.MapValueFrom(ctx, types.StringType, img.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		imagesList = append(imagesList, item)
+	}
+
+	imagesValue, diags := types.ListValueFrom(ctx, types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":           types.StringType,
+			"repo_tags":    types.ListType{ElemType: types.StringType},
+			"repo_digests": types.ListType{ElemType: types.StringType},
+			"size":         types.Int64Type,
+			"created":      types.StringType,
+			"labels":       types.MapType{ElemType: types.StringType},
+		},
+	}, imagesList)
+	resp.Diagnostics.Append(diags...)
+
+	// Set data
+	state := ImagesDataSourceModel{
+		EnvironmentID: config.EnvironmentID,
+		Filter:        config.Filter,
+		Images:        imagesValue,
+		ID:            types.StringValue(config.EnvironmentID.ValueString()),
+	}
+
+	tflog.Trace(ctx, "Read images data source")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
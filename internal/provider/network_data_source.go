@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &NetworkDataSource{}
+
+// NewNetworkDataSource is a helper function to simplify the provider implementation.
+func NewNetworkDataSource() datasource.DataSource {
+	return &NetworkDataSource{}
+}
+
+// NetworkDataSource is the data source implementation.
+type NetworkDataSource struct {
+	client *client.Client
+}
+
+// NetworkDataSourceModel describes the data source data model.
+type NetworkDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Driver        types.String `tfsdk:"driver"`
+	Scope         types.String `tfsdk:"scope"`
+	Labels        types.Map    `tfsdk:"labels"`
+	IPAM          types.Object `tfsdk:"ipam"`
+	Containers    types.List   `tfsdk:"containers"`
+}
+
+// networkIPAMConfigAttrTypes describes a single IPAM pool.
+var networkIPAMConfigAttrTypes = map[string]attr.Type{
+	"subnet":  types.StringType,
+	"gateway": types.StringType,
+}
+
+// networkIPAMAttrTypes describes a network's IPAM configuration.
+var networkIPAMAttrTypes = map[string]attr.Type{
+	"driver":  types.StringType,
+	"config":  types.ListType{ElemType: types.ObjectType{AttrTypes: networkIPAMConfigAttrTypes}},
+	"options": types.MapType{ElemType: types.StringType},
+}
+
+// Metadata returns the data source type name.
+func (d *NetworkDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+// Schema defines the schema for the data source.
+func (d *NetworkDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single network in a Dockhand environment by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The network ID. Exactly one of `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The network name. Exactly one of `id` or `name` must be set.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of network.",
+			},
+			"driver": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The network driver.",
+			},
+			"scope": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The scope of the network.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels for the network.",
+			},
+			"ipam": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "IPAM configuration.",
+				Attributes: map[string]schema.Attribute{
+					"driver": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The IPAM driver.",
+					},
+					"config": schema.ListNestedAttribute{
+						Computed:            true,
+						MarkdownDescription: "Address pools.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"subnet": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "The subnet in CIDR notation.",
+								},
+								"gateway": schema.StringAttribute{
+									Computed:            true,
+									MarkdownDescription: "The gateway address.",
+								},
+							},
+						},
+					},
+					"options": schema.MapAttribute{
+						Computed:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "IPAM driver options.",
+					},
+				},
+			},
+			"containers": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the containers attached to the network.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *NetworkDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config NetworkDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	validateLookupKeys(config.ID, config.Name, "network", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := config.EnvironmentID.ValueString()
+
+	var network *client.Network
+	if !config.ID.IsNull() {
+		n, err := d.client.GetNetwork(environmentID, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading network",
+				"Could not read network: "+err.Error(),
+			)
+			return
+		}
+		network = n
+	} else {
+		networks, err := d.client.ListNetworks(environmentID, &client.ListFilter{Name: config.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading networks",
+				"Could not read networks: "+err.Error(),
+			)
+			return
+		}
+
+		network = lookupByName(networks, config.Name.ValueString(), func(n client.Network) string { return n.Name }, "network", &resp.Diagnostics)
+		if network == nil {
+			return
+		}
+	}
+
+	state := NetworkDataSourceModel{
+		ID:            types.StringValue(network.ID),
+		EnvironmentID: config.EnvironmentID,
+		Name:          types.StringValue(network.Name),
+		Type:          types.StringValue(network.Type),
+		Driver:        types.StringValue(network.Driver),
+		Scope:         types.StringValue(network.Scope),
+	}
+
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, network.Labels)
+	resp.Diagnostics.Append(diags...)
+
+	state.IPAM, diags = flattenNetworkIPAM(ctx, network.IPAM)
+	resp.Diagnostics.Append(diags...)
+
+	state.Containers, diags = types.ListValueFrom(ctx, types.StringType, networkContainerIDs(network))
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read network data source", map[string]any{"id": network.ID})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenNetworkIPAM converts a network's IPAM configuration into an object.
+func flattenNetworkIPAM(ctx context.Context, ipam *client.NetworkIPAM) (types.Object, diag.Diagnostics) {
+	if ipam == nil {
+		return types.ObjectNull(networkIPAMAttrTypes), nil
+	}
+
+	var diags diag.Diagnostics
+
+	pools := make([]map[string]attr.Value, 0, len(ipam.Config))
+	for _, c := range ipam.Config {
+		pools = append(pools, map[string]attr.Value{
+			"subnet":  types.StringValue(c.Subnet),
+			"gateway": types.StringValue(c.Gateway),
+		})
+	}
+
+	config, d := objectListValue(networkIPAMConfigAttrTypes, pools)
+	diags.Append(d...)
+
+	options, d := types.MapValueFrom(ctx, types.StringType, ipam.Options)
+	diags.Append(d...)
+
+	obj, d := types.ObjectValue(networkIPAMAttrTypes, map[string]attr.Value{
+		"driver":  types.StringValue(ipam.Driver),
+		"config":  config,
+		"options": options,
+	})
+	diags.Append(d...)
+
+	return obj, diags
+}
+
+// networkContainerIDs returns the sorted IDs of the containers attached to a
+// network.
+func networkContainerIDs(network *client.Network) []string {
+	ids := make([]string, 0, len(network.Containers))
+	for id := range network.Containers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
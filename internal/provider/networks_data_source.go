@@ -2,34 +2,213 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
 
+// Ensure the implementation defined in this package is a datasource.DataSource
 var _ datasource.DataSource = &NetworksDataSource{}
 
+// NewNetworksDataSource is a helper function to simplify the provider implementation.
 func NewNetworksDataSource() datasource.DataSource {
 	return &NetworksDataSource{}
 }
 
+// NetworksDataSource is the data source implementation.
 type NetworksDataSource struct {
 	client *client.Client
 }
 
+// NetworksDataSourceModel describes the data source data model.
+type NetworksDataSourceModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Filter        types.Object `tfsdk:"filter"`
+	Networks      types.List   `tfsdk:"networks"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// NetworksFilterModel describes the filter attribute of the data source.
+type NetworksFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+	Driver    types.String `tfsdk:"driver"`
+}
+
+// NetworkData describes a network in the data source
+type NetworkData struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Driver types.String `tfsdk:"driver"`
+	Scope  types.String `tfsdk:"scope"`
+	Labels types.Map    `tfsdk:"labels"`
+}
+
+// Metadata returns the data source type name.
 func (d *NetworksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_networks"
 }
 
+// Schema defines the schema for the data source.
 func (d *NetworksDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Fetches a list of networks in a Dockhand environment.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The data source ID.",
+			},
+			"filter": listFilterAttribute(map[string]schema.Attribute{
+				"driver": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include networks using this driver.",
+				},
+			}),
+			"networks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of networks.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The network ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The network name.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of network.",
+						},
+						"driver": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The network driver.",
+						},
+						"scope": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The scope of the network.",
+						},
+						"labels": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The labels.",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
+// Configure adds the provider configured client to the data source.
 func (d *NetworksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
 }
 
+// Read refreshes the Terraform state with the latest data.
 func (d *NetworksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config NetworksDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filterModel NetworksFilterModel
+	if !config.Filter.IsNull() {
+		resp.Diagnostics.Append(config.Filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{})...)
+	}
+
+	filter := newListFilter(ctx, filterModel.NameRegex, filterModel.Labels, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get networks
+	networks, err := d.client.ListNetworks(config.EnvironmentID.ValueString(), &client.ListFilter{Labels: filter.labels})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading networks",
+			"Could not read networks: "+err.Error(),
+		)
+		return
+	}
+
+	// Convert to Terraform types
+	networksList := []NetworkData{}
+	for _, n := range networks {
+		if !filter.matchName(n.Name) || !filter.matchLabels(n.Labels) {
+			continue
+		}
+		if driver := filterModel.Driver.ValueString(); driver != "" && n.Driver != driver {
+			continue
+		}
+
+		item := NetworkData{
+			ID:     types.StringValue(n.ID),
+			Name:   types.StringValue(n.Name),
+			Type:   types.StringValue(n.Type),
+			Driver: types.StringValue(n.Driver),
+			Scope:  types.StringValue(n.Scope),
+		}
+
+		item.Labels, diags = types.MapValueFrom(ctx, types.StringType, n.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		networksList = append(networksList, item)
+	}
+
+	networksValue, diags := types.ListValueFrom(ctx, types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":     types.StringType,
+			"name":   types.StringType,
+			"type":   types.StringType,
+			"driver": types.StringType,
+			"scope":  types.StringType,
+			"labels": types.MapType{ElemType: types.StringType},
+		},
+	}, networksList)
+	resp.Diagnostics.Append(diags...)
+
+	// Set data
+	state := NetworksDataSourceModel{
+		EnvironmentID: config.EnvironmentID,
+		Filter:        config.Filter,
+		Networks:      networksValue,
+		ID:            types.StringValue(config.EnvironmentID.ValueString()),
+	}
+
+	tflog.Trace(ctx, "Read networks data source")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
@@ -150,23 +150,33 @@ func (p *DockhandProvider) Configure(ctx context.Context, req provider.Configure
 func (p *DockhandProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewContainerResource,
+		NewContainerExecResource,
 		NewComposeStackResource,
 		NewEnvironmentResource,
 		NewNetworkResource,
 		NewVolumeResource,
 		NewImageResource,
 		NewImagePullResource,
+		NewImageTagResource,
 	}
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *DockhandProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewContainerDataSource,
 		NewContainersDataSource,
+		NewContainerLogsDataSource,
+		NewContainerStatsDataSource,
+		NewComposeStackDataSource,
 		NewComposeStacksDataSource,
+		NewEnvironmentDataSource,
 		NewEnvironmentsDataSource,
+		NewNetworkDataSource,
 		NewNetworksDataSource,
+		NewVolumeDataSource,
 		NewVolumesDataSource,
+		NewImageDataSource,
 		NewImagesDataSource,
 	}
 }
@@ -1,9 +1,27 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
 
 func TestBuildClientConfigFromModel(t *testing.T) {
@@ -32,3 +50,1072 @@ func TestBuildClientConfigFromModelEmpty(t *testing.T) {
 		t.Fatalf("expected empty client config for zero model, got: %+v", cfg)
 	}
 }
+
+func TestSplitImageReference(t *testing.T) {
+	cases := map[string][2]string{
+		"nginx":                             {"nginx", "latest"},
+		"nginx:1.25":                        {"nginx", "1.25"},
+		"registry:5000/team/app":            {"registry:5000/team/app", "latest"},
+		"registry:5000/team/app:v2":         {"registry:5000/team/app", "v2"},
+		"registry.company.com/nginx:stable": {"registry.company.com/nginx", "stable"},
+		"nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31":                {"nginx", "latest"},
+		"registry:5000/app:v2@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31": {"registry:5000/app", "v2"},
+	}
+
+	for ref, want := range cases {
+		repo, tag := splitImageReference(ref)
+		if repo != want[0] || tag != want[1] {
+			t.Fatalf("splitImageReference(%q) = %q, %q; want %q, %q", ref, repo, tag, want[0], want[1])
+		}
+	}
+}
+
+func TestFindImageByReference(t *testing.T) {
+	images := []client.Image{
+		{ID: "sha256:aaa", RepoTags: []string{"nginx:latest"}, RepoDigests: []string{"nginx@sha256:111"}},
+		{ID: "sha256:bbb", RepoTags: []string{"registry.company.com/app:v1"}},
+	}
+
+	cases := map[string]string{
+		"nginx":                          "sha256:aaa",
+		"docker.io/library/nginx:latest": "sha256:aaa",
+		"nginx@sha256:111":               "sha256:aaa",
+		"bbb":                            "sha256:bbb",
+		"registry.company.com/app:v1":    "sha256:bbb",
+		"registry.company.com/app":       "",
+		"redis:7":                        "",
+	}
+
+	for ref, want := range cases {
+		got := ""
+		if img := findImageByReference(images, ref); img != nil {
+			got = img.ID
+		}
+		if got != want {
+			t.Fatalf("findImageByReference(%q) = %q; want %q", ref, got, want)
+		}
+	}
+}
+
+func TestLookupByName(t *testing.T) {
+	networks := []client.Network{
+		{ID: "n1", Name: "frontend"},
+		{ID: "n2", Name: "backend"},
+		{ID: "n3", Name: "backend"},
+	}
+	nameOf := func(n client.Network) string { return n.Name }
+
+	var diags diag.Diagnostics
+	if n := lookupByName(networks, "frontend", nameOf, "network", &diags); n == nil || n.ID != "n1" || diags.HasError() {
+		t.Fatalf("expected frontend network, got %+v (%v)", n, diags)
+	}
+
+	diags = nil
+	if n := lookupByName(networks, "backend", nameOf, "network", &diags); n != nil || !diags.HasError() {
+		t.Fatalf("expected an error for ambiguous name, got %+v", n)
+	}
+
+	diags = nil
+	if n := lookupByName(networks, "missing", nameOf, "network", &diags); n != nil || !diags.HasError() {
+		t.Fatalf("expected an error for unknown name, got %+v", n)
+	}
+}
+
+func TestListFilterMatch(t *testing.T) {
+	var diags diag.Diagnostics
+	f := newListFilter(context.Background(), types.StringValue("^web-"), types.MapNull(types.StringType), &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	f.labels = map[string]string{"tier": "frontend"}
+
+	if !f.matchName("db", "web-1") {
+		t.Fatal("expected web-1 to match")
+	}
+	if f.matchName("api") {
+		t.Fatal("expected api not to match")
+	}
+	if !f.matchLabels(map[string]string{"tier": "frontend", "app": "shop"}) {
+		t.Fatal("expected labels to match")
+	}
+	if f.matchLabels(map[string]string{"tier": "backend"}) {
+		t.Fatal("expected labels not to match")
+	}
+
+	diags = nil
+	newListFilter(context.Background(), types.StringValue("("), types.MapNull(types.StringType), &diags)
+	if !diags.HasError() {
+		t.Fatal("expected an error for an invalid regular expression")
+	}
+}
+
+func TestSplitLogLines(t *testing.T) {
+	if lines := splitLogLines(""); len(lines) != 0 {
+		t.Fatalf("expected no lines, got %v", lines)
+	}
+
+	lines := splitLogLines("starting\r\nlistening on :80\n")
+	if len(lines) != 2 || lines[0] != "starting" || lines[1] != "listening on :80" {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+
+	if got := formatLogTail("web", ""); got != "" {
+		t.Fatalf("expected empty detail, got %q", got)
+	}
+	if got := formatLogTail("web", "boom\n"); !strings.Contains(got, "Last 1 log lines") || !strings.HasSuffix(got, "boom") {
+		t.Fatalf("unexpected detail: %q", got)
+	}
+}
+
+func TestAggregateContainerStats(t *testing.T) {
+	total := aggregateContainerStats([]client.ContainerStats{
+		{ID: "a", CPUPercent: 12.5, MemoryUsage: 100, MemoryLimit: 400, NetworkRxBytes: 10, PIDs: 3},
+		{ID: "b", CPUPercent: 7.5, MemoryUsage: 100, MemoryLimit: 400, NetworkRxBytes: 5, PIDs: 2},
+	})
+
+	if total.ID != "" || total.CPUPercent != 20 || total.MemoryUsage != 200 || total.MemoryLimit != 800 {
+		t.Fatalf("unexpected totals: %+v", total)
+	}
+	if total.MemoryPercent != 25 || total.NetworkRxBytes != 15 || total.PIDs != 5 {
+		t.Fatalf("unexpected totals: %+v", total)
+	}
+
+	if empty := aggregateContainerStats(nil); empty.MemoryPercent != 0 {
+		t.Fatalf("expected zero totals, got %+v", empty)
+	}
+}
+
+func TestEnvList(t *testing.T) {
+	got := envList(map[string]string{"B": "2", "A": "x=y"})
+	if len(got) != 2 || got[0] != "A=x=y" || got[1] != "B=2" {
+		t.Fatalf("unexpected env list: %v", got)
+	}
+	if got := envList(nil); len(got) != 0 {
+		t.Fatalf("expected empty env list, got %v", got)
+	}
+}
+
+func TestChangedUploads(t *testing.T) {
+	upload := func(file, hash, perms string) ContainerUploadModel {
+		return ContainerUploadModel{
+			File:        types.StringValue(file),
+			ContentHash: types.StringValue(hash),
+			Permissions: types.StringValue(perms),
+			Owner:       types.StringNull(),
+		}
+	}
+
+	state := []ContainerUploadModel{upload("/a", "h1", "0644"), upload("/b", "h2", "0644")}
+	plan := []ContainerUploadModel{upload("/a", "h1", "0644"), upload("/b", "h3", "0644"), upload("/c", "h4", "0600")}
+
+	changed := changedUploads(plan, state)
+	if len(changed) != 2 || changed[0].File.ValueString() != "/b" || changed[1].File.ValueString() != "/c" {
+		t.Fatalf("unexpected changed uploads: %+v", changed)
+	}
+}
+
+func TestParseUploadOptions(t *testing.T) {
+	if mode, err := parseFileMode("0755"); err != nil || mode != 0o755 {
+		t.Fatalf("parseFileMode(0755) = %o, %v", mode, err)
+	}
+	for _, s := range []string{"", "rw", "99999"} {
+		if _, err := parseFileMode(s); err == nil {
+			t.Fatalf("expected an error for permissions %q", s)
+		}
+	}
+
+	owner, err := parseOwner("1000:www-data")
+	if err != nil || owner.UID != 1000 || owner.Gname != "www-data" {
+		t.Fatalf("unexpected owner: %+v, %v", owner, err)
+	}
+	if _, err := parseOwner("root"); err == nil {
+		t.Fatal("expected an error for an owner without group")
+	}
+}
+
+func TestValidateRuntimeOptions(t *testing.T) {
+	ctx := context.Background()
+	list := func(values ...string) types.List {
+		l, _ := types.ListValueFrom(ctx, types.StringType, values)
+		return l
+	}
+
+	valid := ContainerResourceModel{
+		User:        types.StringValue("1000:1000"),
+		WorkingDir:  types.StringValue("/srv/app"),
+		Hostname:    types.StringValue("web-1"),
+		Domainname:  types.StringValue("example.com"),
+		DNS:         list("1.1.1.1", "2606:4700:4700::1111"),
+		DNSSearch:   list("svc.local"),
+		ExtraHosts:  list("db:10.0.0.5", "host.docker.internal:host-gateway", "v6:::1"),
+		StopSignal:  types.StringValue("SIGQUIT"),
+		StopTimeout: types.Int64Value(30),
+		Entrypoint:  types.ListNull(types.StringType),
+	}
+	var diags diag.Diagnostics
+	valid.validateRuntimeOptions(ctx, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	invalid := ContainerResourceModel{
+		User:        types.StringValue("a:b:c"),
+		WorkingDir:  types.StringValue("relative"),
+		Hostname:    types.StringValue("-bad"),
+		Domainname:  types.StringValue("bad_domain"),
+		DNS:         list("dns.google"),
+		DNSSearch:   list("ok.local"),
+		ExtraHosts:  list("db"),
+		StopSignal:  types.StringValue("term"),
+		StopTimeout: types.Int64Value(-5),
+	}
+	diags = nil
+	invalid.validateRuntimeOptions(ctx, &diags)
+	if diags.ErrorsCount() != 8 {
+		t.Fatalf("expected 8 errors, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestValidateSecurityOptions(t *testing.T) {
+	ctx := context.Background()
+	list := func(values ...string) types.List {
+		l, _ := types.ListValueFrom(ctx, types.StringType, values)
+		return l
+	}
+	caps, _ := types.ObjectValue(containerCapabilitiesAttrTypes, map[string]attr.Value{
+		"add":  list("NET_ADMIN", "cap_bad"),
+		"drop": list("ALL"),
+	})
+	sysctls, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"net.core.somaxconn": "1024", "bad": "1"})
+
+	m := ContainerResourceModel{
+		Privileged:   types.BoolValue(true),
+		Mounts:       list("/var/run/docker.sock:/var/run/docker.sock", "/data:/data"),
+		UsernsMode:   types.StringValue("private"),
+		SecurityOpts: list("seccomp=unconfined", "label", "no-new-privileges"),
+		Sysctls:      sysctls,
+		Capabilities: caps,
+	}
+
+	var diags diag.Diagnostics
+	m.validateSecurityOptions(ctx, &diags)
+	if diags.WarningsCount() != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", diags.WarningsCount(), diags)
+	}
+	if diags.ErrorsCount() != 4 {
+		t.Fatalf("expected 4 errors, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{
+		"1048576": 1048576,
+		"512m":    512 << 20,
+		"2G":      2 << 30,
+		"1.5g":    3 << 29,
+		"64mb":    64 << 20,
+		"4k":      4096,
+	}
+	for in, want := range cases {
+		got, err := parseByteSize(in)
+		if err != nil || got != want {
+			t.Fatalf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "m", "12x", "-1", "9999999t", "8388608t"} {
+		if _, err := parseByteSize(in); err == nil {
+			t.Fatalf("expected an error for %q", in)
+		}
+	}
+
+	if got := refreshByteSize(types.StringValue("512m"), 512<<20); got.ValueString() != "512m" {
+		t.Fatalf("expected configured size to be kept, got %q", got.ValueString())
+	}
+	if got := refreshByteSize(types.StringValue("512m"), 1<<30); got.ValueString() != "1073741824" {
+		t.Fatalf("expected remote bytes, got %q", got.ValueString())
+	}
+	if got := refreshByteSize(types.StringNull(), 1<<30); !got.IsNull() {
+		t.Fatalf("expected null, got %q", got.ValueString())
+	}
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	ctx := context.Background()
+	ulimits, _ := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: containerUlimitAttrTypes}, []ContainerUlimitModel{
+		{Name: types.StringValue("nofile"), Soft: types.Int64Value(1024), Hard: types.Int64Value(4096)},
+		{Name: types.StringValue("files"), Soft: types.Int64Value(10), Hard: types.Int64Value(5)},
+	})
+
+	m := ContainerResourceModel{
+		MemoryReservation: types.StringValue("256m"),
+		MemorySwap:        types.StringValue("-1"),
+		ShmSize:           types.StringValue("lots"),
+		CPUShares:         types.Int64Value(1),
+		CpusetCpus:        types.StringValue("0-3,5"),
+		PidsLimit:         types.Int64Value(100),
+		Ulimits:           ulimits,
+	}
+
+	var diags diag.Diagnostics
+	m.validateResourceLimits(ctx, &diags)
+	if diags.ErrorsCount() != 4 {
+		t.Fatalf("expected 4 errors, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestValidateLogConfig(t *testing.T) {
+	ctx := context.Background()
+	opts := func(values map[string]string) types.Map {
+		m, _ := types.MapValueFrom(ctx, types.StringType, values)
+		return m
+	}
+
+	cases := []struct {
+		driver   types.String
+		opts     types.Map
+		errors   int
+		warnings int
+	}{
+		{types.StringValue("json-file"), opts(map[string]string{"max-size": "10m", "max-file": "3"}), 0, 0},
+		{types.StringValue("json-file"), opts(map[string]string{"max-size": "ten", "max-file": "0"}), 2, 0},
+		{types.StringValue("syslog"), opts(map[string]string{"syslog-address": "udp://logs:514"}), 0, 0},
+		{types.StringValue("syslog"), opts(map[string]string{"syslog-address": "logs:514"}), 1, 0},
+		{types.StringValue("loki"), types.MapNull(types.StringType), 1, 0},
+		{types.StringValue("custom/plugin"), types.MapNull(types.StringType), 0, 1},
+		{types.StringNull(), opts(map[string]string{"max-size": "10m"}), 1, 0},
+	}
+
+	for i, tc := range cases {
+		var diags diag.Diagnostics
+		ContainerResourceModel{LogDriver: tc.driver, LogOpts: tc.opts}.validateLogConfig(ctx, &diags)
+		if diags.ErrorsCount() != tc.errors || diags.WarningsCount() != tc.warnings {
+			t.Fatalf("case %d: got %d errors and %d warnings: %v", i, diags.ErrorsCount(), diags.WarningsCount(), diags)
+		}
+	}
+}
+
+func TestValidateEnv(t *testing.T) {
+	env := func(values map[string]string) types.Map {
+		m, _ := types.MapValueFrom(context.Background(), types.StringType, values)
+		return m
+	}
+
+	cases := []struct {
+		env       types.Map
+		sensitive types.Map
+		errors    int
+	}{
+		{env(map[string]string{"APP_ENV": "prod"}), env(map[string]string{"DB_PASSWORD": "secret"}), 0},
+		{env(map[string]string{"1BAD": "x", "HAS SPACE": "x"}), types.MapNull(types.StringType), 2},
+		{env(map[string]string{"DB_PASSWORD": "x"}), env(map[string]string{"DB_PASSWORD": "secret"}), 1},
+		{types.MapNull(types.StringType), env(map[string]string{"BAD=KEY": "secret"}), 1},
+	}
+
+	for i, tc := range cases {
+		var diags diag.Diagnostics
+		ContainerResourceModel{Env: tc.env, EnvSensitive: tc.sensitive}.validateEnv(context.Background(), &diags)
+		if diags.ErrorsCount() != tc.errors {
+			t.Fatalf("case %d: got %d errors, want %d: %v", i, diags.ErrorsCount(), tc.errors, diags)
+		}
+	}
+}
+
+func TestRefreshEnvKeepsSensitiveValuesSeparate(t *testing.T) {
+	ctx := context.Background()
+	model := ContainerResourceModel{
+		Env:          types.MapValueMust(types.StringType, map[string]attr.Value{"APP_ENV": types.StringValue("prod")}),
+		EnvSensitive: types.MapValueMust(types.StringType, map[string]attr.Value{"DB_PASSWORD": types.StringValue("old")}),
+	}
+
+	container := &client.Container{Env: []string{"PATH=/usr/bin", "APP_ENV=staging", "DB_PASSWORD=new"}}
+	if diags := model.refreshEnv(ctx, container); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var env, sensitive map[string]string
+	model.Env.ElementsAs(ctx, &env, false)
+	model.EnvSensitive.ElementsAs(ctx, &sensitive, false)
+
+	if len(env) != 1 || env["APP_ENV"] != "staging" {
+		t.Fatalf("unexpected env: %v", env)
+	}
+	if len(sensitive) != 1 || sensitive["DB_PASSWORD"] != "new" {
+		t.Fatalf("unexpected env_sensitive: %v", sensitive)
+	}
+}
+
+func TestTriggersChanged(t *testing.T) {
+	triggers := func(values map[string]string) types.Map {
+		m, _ := types.MapValueFrom(context.Background(), types.StringType, values)
+		return m
+	}
+	restart := types.StringNull()
+	recreate := types.StringValue(triggerActionRecreate)
+
+	cases := []struct {
+		state, plan types.Map
+		action      types.String
+		want        bool
+	}{
+		{triggers(map[string]string{"config": "a"}), triggers(map[string]string{"config": "a"}), restart, false},
+		{triggers(map[string]string{"config": "a"}), triggers(map[string]string{"config": "b"}), restart, true},
+		{types.MapNull(types.StringType), triggers(map[string]string{"config": "a"}), restart, true},
+		{types.MapNull(types.StringType), triggers(map[string]string{}), restart, false},
+		{triggers(map[string]string{"config": "a"}), triggers(map[string]string{"config": "b"}), recreate, false},
+	}
+
+	for i, tc := range cases {
+		if got := triggersChanged(tc.state, tc.plan, tc.action); got != tc.want {
+			t.Fatalf("case %d: got %v, want %v", i, got, tc.want)
+		}
+	}
+}
+
+func TestComposeServices(t *testing.T) {
+	services := map[string]client.ComposeService{
+		"web":    {Name: "web", Image: "nginx", Status: "running", Count: 2},
+		"worker": {Image: "app", Status: "exited"},
+		"db":     {Name: "db", Image: "postgres", Status: "restarting"},
+	}
+
+	got := notRunningServices(services)
+	if strings.Join(got, ", ") != "db (restarting), worker (exited)" {
+		t.Fatalf("unexpected services: %v", got)
+	}
+
+	m, diags := flattenComposeServices(services)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	worker := m.Elements()["worker"].(types.Object).Attributes()
+	if worker["name"].(types.String).ValueString() != "worker" || worker["count"].(types.Int64).ValueInt64() != 0 {
+		t.Fatalf("unexpected worker: %v", worker)
+	}
+}
+
+func TestWaitForServices(t *testing.T) {
+	statuses := []string{"created", "running (starting)", "running (healthy)"}
+	calls := 0
+	get := func() (*client.ComposeStack, error) {
+		status := statuses[calls]
+		calls++
+		return &client.ComposeStack{Services: map[string]client.ComposeService{
+			"web":    {Status: status},
+			"worker": {Status: "exited"},
+		}}, nil
+	}
+
+	if _, err := waitForServices(context.Background(), get, []string{"web"}, time.Second, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 polls, got %d", calls)
+	}
+
+	calls = 0
+	_, err := waitForServices(context.Background(), get, nil, 2*time.Millisecond, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "worker: exited") {
+		t.Fatalf("expected failure reason for worker, got %v", err)
+	}
+
+	failures := serviceFailures(map[string]client.ComposeService{"web": {Status: "running (unhealthy)"}}, []string{"web", "db"})
+	if strings.Join(failures, "; ") != "db: not found in the stack; web: running (unhealthy)" {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+}
+
+func TestParseEnvFile(t *testing.T) {
+	env, err := parseEnvFile("# database\nexport DB_HOST=db\nDB_PORT = 5432\n\nGREETING=\"hello world\"\nEMPTY=\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env) != 4 || env["DB_HOST"] != "db" || env["DB_PORT"] != "5432" || env["GREETING"] != "hello world" || env["EMPTY"] != "" {
+		t.Fatalf("unexpected env: %v", env)
+	}
+
+	if _, err := parseEnvFile("OK=1\nnot a variable\n"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected an error on line 2, got %v", err)
+	}
+}
+
+func TestGitRefDrifted(t *testing.T) {
+	deployed := "3f2a9c1e5b7d4f6a8c0e2b4d6f8a0c2e4b6d8f0a"
+	other := "0123456789abcdef0123456789abcdef01234567"
+
+	cases := []struct {
+		ref  types.String
+		want bool
+	}{
+		{types.StringValue(deployed), false},
+		{types.StringValue(other), true},
+		{types.StringValue("main"), false},
+		{types.StringValue("v1.2.0"), false},
+		{types.StringNull(), false},
+	}
+
+	for i, tc := range cases {
+		if got := gitRefDrifted(tc.ref, deployed); got != tc.want {
+			t.Fatalf("case %d: got %v, want %v", i, got, tc.want)
+		}
+	}
+
+	if gitRefDrifted(types.StringValue(other), "") {
+		t.Fatal("expected no drift when no commit is deployed")
+	}
+}
+
+func TestUpdatedServices(t *testing.T) {
+	pulls := []client.ComposeImagePull{
+		{Service: "web", Image: "nginx:latest", PreviousDigest: "sha256:aaa", Digest: "sha256:bbb"},
+		{Service: "db", Image: "postgres:16", PreviousDigest: "sha256:ccc", Digest: "sha256:ccc"},
+		{Service: "cache", Image: "redis:7", Digest: "sha256:ddd"},
+		{Service: "app", Image: "app:dev"},
+	}
+
+	if got := strings.Join(updatedServices(pulls), ","); got != "cache,web" {
+		t.Fatalf("unexpected updated services: %q", got)
+	}
+
+	var diags diag.Diagnostics
+	triggers, _ := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"date": "2024-01-01"})
+	validatePullPolicy(types.StringValue(pullPolicyNever), triggers, &diags)
+	validatePullPolicy(types.StringValue("sometimes"), types.MapNull(types.StringType), &diags)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestComposeStackDataOmitsGitSecrets(t *testing.T) {
+	repo, diags := flattenComposeGitRepo(&client.GitRepository{
+		URL:  "https://github.com/example/app.git",
+		Ref:  "v1.2.0",
+		Auth: &client.GitAuth{Type: "https", Token: "secret"},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if _, ok := repo.Attributes()["auth_token"]; ok || repo.Attributes()["ref"].(types.String).ValueString() != "v1.2.0" {
+		t.Fatalf("unexpected git repo: %v", repo)
+	}
+
+	item := ComposeStackData{
+		ID:             types.StringValue("1"),
+		Name:           types.StringValue("app"),
+		Compose:        types.StringValue("services: {}"),
+		Status:         types.StringValue("running"),
+		DesiredStatus:  types.StringValue("running"),
+		Labels:         types.MapNull(types.StringType),
+		PullPolicy:     types.StringValue(""),
+		AutoSync:       types.BoolValue(false),
+		GitRepo:        repo,
+		DeployedCommit: types.StringValue(""),
+		Services:       types.MapNull(types.ObjectType{AttrTypes: composeServiceAttrTypes}),
+		CreatedAt:      types.StringValue(""),
+		UpdatedAt:      types.StringValue(""),
+	}
+	if _, diags := types.ListValueFrom(context.Background(), types.ObjectType{AttrTypes: composeStackDataAttrTypes}, []ComposeStackData{item}); diags.HasError() {
+		t.Fatalf("item does not match its attribute types: %v", diags)
+	}
+}
+
+func TestValidateLookupKeys(t *testing.T) {
+	cases := []struct {
+		id, name types.String
+		errors   int
+	}{
+		{types.StringValue("abc"), types.StringNull(), 0},
+		{types.StringNull(), types.StringValue("web"), 0},
+		{types.StringNull(), types.StringNull(), 1},
+		{types.StringValue("abc"), types.StringValue("web"), 1},
+		{types.StringValue(""), types.StringValue("web"), 2},
+		{types.StringValue(""), types.StringNull(), 1},
+	}
+
+	for i, tc := range cases {
+		var diags diag.Diagnostics
+		validateLookupKeys(tc.id, tc.name, "container", &diags)
+		if diags.ErrorsCount() != tc.errors {
+			t.Fatalf("case %d: expected %d errors, got %v", i, tc.errors, diags)
+		}
+	}
+}
+
+func TestStartupFailure(t *testing.T) {
+	cases := []struct {
+		state, status string
+		failed        bool
+	}{
+		{"running", "Up 2 seconds", false},
+		{"running", "Up 2 seconds (health: starting)", false},
+		{"running", "Up 40 seconds (unhealthy)", true},
+		{"exited", "Exited (0) 1 second ago", false},
+		{"exited", "Exited (1) 1 second ago", true},
+		{"restarting", "Restarting (137) 1 second ago", true},
+	}
+
+	for _, tc := range cases {
+		got := startupFailure(&client.Container{State: tc.state, Status: tc.status})
+		if (got != "") != tc.failed {
+			t.Fatalf("startupFailure(%q, %q) = %q, want failed=%v", tc.state, tc.status, got, tc.failed)
+		}
+	}
+}
+
+// apiRequest is a request received by a test API server.
+type apiRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   map[string]any // JSON bodies only
+}
+
+// newTestAPI starts an API server that records every request and answers it
+// with handler, and returns a client for it and the recorded requests.
+func newTestAPI(t *testing.T, handler http.HandlerFunc) (*client.Client, *[]apiRequest) {
+	t.Helper()
+
+	var requests []apiRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := apiRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query()}
+		if body, _ := io.ReadAll(r.Body); len(body) > 0 && strings.Contains(r.Header.Get("Content-Type"), "json") {
+			if err := json.Unmarshal(body, &req.Body); err != nil {
+				t.Errorf("%s %s: invalid body: %v", r.Method, r.URL.Path, err)
+			}
+		}
+		requests = append(requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return client.NewClient(&client.Config{Endpoint: srv.URL, Timeout: 5}), &requests
+}
+
+// newTestState returns the state of a resource with the given attributes set
+// and every other attribute null.
+func newTestState(t *testing.T, res resource.Resource, values map[string]any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range values {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("setting %s: %v", name, diags)
+		}
+	}
+
+	return state
+}
+
+// methods returns the method and path of each request.
+func methods(requests []apiRequest) []string {
+	calls := make([]string, len(requests))
+	for i, r := range requests {
+		calls[i] = r.Method + " " + r.Path
+	}
+
+	return calls
+}
+
+func TestContainerUpdateAppliesLimitsInPlace(t *testing.T) {
+	ctx := context.Background()
+	apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"abc","name":"web","image":"nginx","state":"running","status":"Up 1 hour","memory":1073741824}`)
+	})
+	r := &ContainerResource{client: apiClient}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	for _, name := range []string{"memory", "memory_reservation", "memory_swap", "cpu_shares", "cpuset_cpus", "pids_limit"} {
+		if a, ok := schemaResp.Schema.Attributes[name].(schema.StringAttribute); ok && len(a.PlanModifiers) > 0 {
+			t.Fatalf("%s has plan modifiers that may replace the container", name)
+		}
+		if a, ok := schemaResp.Schema.Attributes[name].(schema.Int64Attribute); ok && len(a.PlanModifiers) > 0 {
+			t.Fatalf("%s has plan modifiers that may replace the container", name)
+		}
+	}
+
+	values := map[string]any{
+		"id":             "abc",
+		"environment_id": "1",
+		"name":           "web",
+		"image":          "nginx",
+		"state":          "running",
+		"memory":         "512m",
+	}
+	state := newTestState(t, r, values)
+	values["memory"] = "1g"
+	plan := newTestState(t, r, values)
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if got := methods(*requests); len(got) != 1 || got[0] != "PUT /api/environments/1/containers/abc" {
+		t.Fatalf("expected a single in-place update, got %v", got)
+	}
+	if got := (*requests)[0].Body["memory"]; got != float64(1<<30) {
+		t.Fatalf("expected memory of 1073741824 bytes, got %v", got)
+	}
+
+	var memory types.String
+	resp.State.GetAttribute(ctx, path.Root("memory"), &memory)
+	if memory.ValueString() != "1g" {
+		t.Fatalf("expected memory to stay as written, got %s", memory)
+	}
+}
+
+func TestUpgradeContainerStateV1(t *testing.T) {
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"abc","memory":536870912,"cpus":1.5}`)}}
+	var resp resource.UpgradeStateResponse
+	upgradeContainerStateV1(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if got := string(resp.DynamicValue.JSON); got != `{"cpus":1.5,"id":"abc","memory":"536870912"}` {
+		t.Fatalf("unexpected upgraded state: %s", got)
+	}
+}
+
+func TestContainerUpdateClearsEnv(t *testing.T) {
+	ctx := context.Background()
+	apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id":"abc","name":"web","image":"nginx","state":"running"}`)
+	})
+	r := &ContainerResource{client: apiClient}
+
+	values := map[string]any{"id": "abc", "environment_id": "1", "name": "web", "image": "nginx"}
+	plan := newTestState(t, r, values)
+	values["env"] = map[string]string{"DEBUG": "1"}
+	state := newTestState(t, r, values)
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	env, ok := (*requests)[0].Body["env"].([]any)
+	if !ok || len(env) != 0 {
+		t.Fatalf("expected an empty env list in the update, got %v", (*requests)[0].Body["env"])
+	}
+}
+
+func TestUpdateOnlyRecordsDestroyOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("container", func(t *testing.T) {
+		apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"id":"abc","name":"web","image":"nginx","state":"running"}`)
+		})
+		r := &ContainerResource{client: apiClient}
+
+		values := map[string]any{"id": "abc", "environment_id": "1", "name": "web", "image": "nginx", "stop_timeout": int64(10)}
+		state := newTestState(t, r, values)
+		values["force"], values["remove_volumes"], values["trigger_action"] = true, true, "recreate"
+		plan := newTestState(t, r, values)
+
+		resp := resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if got := methods(*requests); len(got) != 1 || got[0] != "GET /api/environments/1/containers/abc" {
+			t.Fatalf("expected the container to be read, not updated, got %v", got)
+		}
+		var force types.Bool
+		resp.State.GetAttribute(ctx, path.Root("force"), &force)
+		if !force.ValueBool() {
+			t.Fatalf("expected force to be recorded in state")
+		}
+	})
+
+	t.Run("compose stack", func(t *testing.T) {
+		apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"id":"abc","name":"app","status":"running"}`)
+		})
+		r := &ComposeStackResource{client: apiClient}
+
+		values := map[string]any{"id": "abc", "environment_id": "1", "name": "app", "compose": "services:\n  web:\n    image: nginx\n"}
+		state := newTestState(t, r, values)
+		values["stop_timeout"], values["remove_orphans"], values["remove_volumes"] = int64(30), true, true
+		values["remove_images"], values["wait_timeout"], values["trigger_action"] = "all", int64(60), "recreate"
+		plan := newTestState(t, r, values)
+
+		resp := resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if got := methods(*requests); len(got) != 1 || got[0] != "GET /api/environments/1/compose-stacks/abc" {
+			t.Fatalf("expected the stack to be read, not redeployed, got %v", got)
+		}
+		var removeImages types.String
+		resp.State.GetAttribute(ctx, path.Root("remove_images"), &removeImages)
+		if removeImages.ValueString() != "all" {
+			t.Fatalf("expected remove_images to be recorded in state, got %s", removeImages)
+		}
+	})
+}
+
+func TestDeletePassesDestroyOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("container", func(t *testing.T) {
+		apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {})
+		r := &ContainerResource{client: apiClient}
+
+		state := newTestState(t, r, map[string]any{
+			"id": "abc", "environment_id": "1", "name": "web", "image": "nginx", "state": "running",
+			"stop_signal": "SIGINT", "stop_timeout": int64(30), "force": true, "remove_volumes": true,
+		})
+		resp := resource.DeleteResponse{State: state}
+		r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		want := []string{"POST /api/environments/1/containers/abc/stop", "DELETE /api/environments/1/containers/abc"}
+		if got := methods(*requests); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		if q := (*requests)[0].Query; q.Get("t") != "30" || q.Get("signal") != "SIGINT" {
+			t.Fatalf("unexpected stop options: %v", q)
+		}
+		if q := (*requests)[1].Query; q.Get("force") != "true" || q.Get("v") != "true" {
+			t.Fatalf("unexpected delete options: %v", q)
+		}
+	})
+
+	t.Run("compose stack", func(t *testing.T) {
+		apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {})
+		r := &ComposeStackResource{client: apiClient}
+
+		state := newTestState(t, r, map[string]any{
+			"id": "abc", "environment_id": "1", "name": "app", "status": "running",
+			"stop_timeout": int64(20), "remove_orphans": true, "remove_volumes": true, "remove_images": "local",
+		})
+		resp := resource.DeleteResponse{State: state}
+		r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		want := []string{"POST /api/environments/1/compose-stacks/abc/stop", "DELETE /api/environments/1/compose-stacks/abc"}
+		if got := methods(*requests); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		if q := (*requests)[0].Query; q.Get("t") != "20" {
+			t.Fatalf("unexpected stop options: %v", q)
+		}
+		if q := (*requests)[1].Query; q.Get("remove_orphans") != "true" || q.Get("volumes") != "true" || q.Get("rmi") != "local" {
+			t.Fatalf("unexpected delete options: %v", q)
+		}
+	})
+}
+
+func TestComposeFilesDrift(t *testing.T) {
+	ctx := context.Background()
+	deployed := "services:\n  web:\n    image: nginx:edited\n"
+	apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {
+		body, _ := json.Marshal(client.ComposeStack{ID: "abc", Name: "app", Status: "running", Compose: deployed})
+		w.Write(body)
+	})
+	r := &ComposeStackResource{client: apiClient}
+
+	values := map[string]any{
+		"id":             "abc",
+		"environment_id": "1",
+		"name":           "app",
+		"compose_files":  []string{"services:\n  web:\n    image: nginx\n", "services:\n  web:\n    image: nginx:1.27\n"},
+	}
+	state := newTestState(t, r, values)
+
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var files []string
+	var recorded ComposeYAML
+	readResp.State.GetAttribute(ctx, path.Root("compose_files"), &files)
+	readResp.State.GetAttribute(ctx, path.Root("compose"), &recorded)
+	if len(files) != 2 {
+		t.Fatalf("expected compose_files to be kept, got %v", files)
+	}
+	if recorded.ValueString() != deployed {
+		t.Fatalf("expected the deployed document to be recorded on compose, got %s", recorded)
+	}
+
+	plan := newTestState(t, r, values)
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: tfsdk.Plan(plan)}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	last := (*requests)[len(*requests)-1]
+	if last.Method != http.MethodPut || !strings.Contains(fmt.Sprint(last.Body["compose"]), "nginx:1.27") {
+		t.Fatalf("expected the merged files to be redeployed, got %s %s", last.Method, last.Path)
+	}
+}
+
+func TestComposeStackModifyPlanSummarizesChanges(t *testing.T) {
+	ctx := context.Background()
+	r := &ComposeStackResource{}
+
+	values := map[string]any{
+		"id":             "abc",
+		"environment_id": "1",
+		"name":           "app",
+		"compose":        "services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n",
+	}
+	state := newTestState(t, r, values)
+	values["compose"] = "services:\n  db: {image: postgres}\n  web:\n    image: caddy\n"
+	plan := newTestState(t, r, values)
+
+	resp := resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 0 || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected one warning, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Warnings()[0].Detail(); !strings.Contains(detail, `service "web" changed: image`) || strings.Contains(detail, `"db"`) {
+		t.Fatalf("unexpected summary: %s", detail)
+	}
+
+	// Reformatting alone is not a change
+	values["compose"] = "# reordered\nservices:\n  db: {image: postgres}\n  web: {image: 'nginx'}\n"
+	plan = newTestState(t, r, values)
+	resp = resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", resp.Diagnostics)
+	}
+}
+
+func TestComposeStackPullTriggersOnlyPull(t *testing.T) {
+	ctx := context.Background()
+	apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/pull"):
+			fmt.Fprint(w, `[{"service":"web","image":"nginx","previous_digest":"sha256:a","digest":"sha256:b"},{"service":"db","image":"postgres","previous_digest":"sha256:c","digest":"sha256:c"}]`)
+		case strings.HasSuffix(r.URL.Path, "/recreate"):
+		default:
+			fmt.Fprint(w, `{"id":"abc","name":"app","status":"running"}`)
+		}
+	})
+	r := &ComposeStackResource{client: apiClient}
+
+	values := map[string]any{
+		"id":             "abc",
+		"environment_id": "1",
+		"name":           "app",
+		"compose":        "services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n",
+		"pull_triggers":  map[string]string{"date": "2026-01-01"},
+	}
+	state := newTestState(t, r, values)
+	values["pull_triggers"] = map[string]string{"date": "2026-01-02"}
+	plan := newTestState(t, r, values)
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	calls := methods(*requests)
+	for _, call := range calls {
+		if strings.HasPrefix(call, http.MethodPut) {
+			t.Fatalf("expected no stack update, got %v", calls)
+		}
+	}
+	if !strings.Contains(strings.Join(calls, ","), "POST /api/environments/1/compose-stacks/abc/pull,POST /api/environments/1/compose-stacks/abc/recreate") {
+		t.Fatalf("expected images to be pulled and services recreated, got %v", calls)
+	}
+	for _, req := range *requests {
+		if strings.HasSuffix(req.Path, "/recreate") && fmt.Sprint(req.Body["services"]) != "[web]" {
+			t.Fatalf("expected only web to be recreated, got %v", req.Body["services"])
+		}
+	}
+}
+
+func TestImageDeleteOnlyRemovesPulledImage(t *testing.T) {
+	ctx := context.Background()
+	apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, _ *http.Request) {})
+	r := &ImageResource{client: apiClient}
+
+	// The tag moved to sha256:new after the resource pulled sha256:old
+	state := newTestState(t, r, map[string]any{
+		"id": "sha256:new", "environment_id": "1", "name": "nginx:latest", "pulled": true, "pulled_id": "sha256:old",
+	})
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if got := methods(*requests); len(got) != 1 || got[0] != "DELETE /api/environments/1/images/sha256:old" {
+		t.Fatalf("expected only the pulled image to be deleted, got %v", got)
+	}
+}
+
+func TestContainerUpdateReuploadsAfterRecreate(t *testing.T) {
+	ctx := context.Background()
+	uploads := []ContainerUploadModel{{
+		File:          types.StringValue("/etc/app.conf"),
+		Content:       types.StringValue("port = 80\n"),
+		ContentBase64: types.StringNull(),
+		Source:        types.StringNull(),
+		Permissions:   types.StringNull(),
+		Owner:         types.StringNull(),
+	}}
+	if err := hashUploads(uploads); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		created string
+		uploads int
+	}{
+		{"2026-01-01T00:00:00Z", 0}, // Updated in place
+		{"2026-02-01T00:00:00Z", 1}, // Recreated
+	} {
+		apiClient, requests := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+			created := "2026-01-01T00:00:00Z"
+			if r.Method == http.MethodPut {
+				created = tc.created
+			}
+			fmt.Fprintf(w, `{"id":"abc","name":"web","image":"nginx","state":"running","created":%q}`, created)
+		})
+		r := &ContainerResource{client: apiClient}
+
+		values := map[string]any{"id": "abc", "environment_id": "1", "name": "web", "image": "nginx", "memory": "512m", "upload": uploads}
+		state := newTestState(t, r, values)
+		values["memory"] = "1g"
+		plan := newTestState(t, r, values)
+
+		resp := resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{State: state, Plan: tfsdk.Plan(plan)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if got := strings.Count(strings.Join(methods(*requests), ","), "/archive"); got != tc.uploads {
+			t.Fatalf("created %s: expected %d uploads, got %v", tc.created, tc.uploads, methods(*requests))
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Actions taken when triggers change.
+const (
+	triggerActionRestart  = "restart"
+	triggerActionRecreate = "recreate"
+)
+
+// triggersAttribute returns the schema of the triggers attribute. target
+// names what is restarted or recreated, e.g. "container".
+func triggersAttribute(target string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		MarkdownDescription: fmt.Sprintf("Arbitrary values, such as hashes of referenced configs or secrets, that restart the %s when they change. "+
+			"Set `trigger_action` to `%s` to replace the %s instead.", target, triggerActionRecreate, target),
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplaceIf(
+				triggersRequireReplace,
+				"Changes replace the resource when trigger_action is recreate.",
+				"Changes replace the resource when `trigger_action` is `"+triggerActionRecreate+"`.",
+			),
+		},
+	}
+}
+
+// triggerActionAttribute returns the schema of the trigger_action attribute.
+func triggerActionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf("What to do when `triggers` change: `%s` or `%s`. Defaults to `%s`.",
+			triggerActionRestart, triggerActionRecreate, triggerActionRestart),
+	}
+}
+
+// triggersRequireReplace requires replacement when trigger_action is recreate.
+func triggersRequireReplace(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+	var action types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("trigger_action"), &action)...)
+
+	resp.RequiresReplace = action.ValueString() == triggerActionRecreate
+}
+
+// validateTriggerAction checks a configured trigger_action.
+func validateTriggerAction(action types.String, diags *diag.Diagnostics) {
+	if action.IsNull() || action.IsUnknown() {
+		return
+	}
+
+	switch action.ValueString() {
+	case triggerActionRestart, triggerActionRecreate:
+	default:
+		diags.AddAttributeError(path.Root("trigger_action"), "Invalid trigger action",
+			fmt.Sprintf("trigger_action must be %q or %q, got %q.", triggerActionRestart, triggerActionRecreate, action.ValueString()))
+	}
+}
+
+// triggersChanged reports whether triggers changed between state and plan
+// such that a restart is needed.
+func triggersChanged(state, plan types.Map, action types.String) bool {
+	if action.ValueString() == triggerActionRecreate {
+		return false
+	}
+
+	if len(state.Elements()) == 0 && len(plan.Elements()) == 0 {
+		return false
+	}
+
+	return !state.Equal(plan)
+}
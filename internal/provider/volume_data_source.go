@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ramorous/terraform-provider-dockhand/internal/client"
+)
+
+// Ensure the implementation defined in this package is a datasource.DataSource
+var _ datasource.DataSource = &VolumeDataSource{}
+
+// NewVolumeDataSource is a helper function to simplify the provider implementation.
+func NewVolumeDataSource() datasource.DataSource {
+	return &VolumeDataSource{}
+}
+
+// VolumeDataSource is the data source implementation.
+type VolumeDataSource struct {
+	client *client.Client
+}
+
+// VolumeDataSourceModel describes the data source data model.
+type VolumeDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Name          types.String `tfsdk:"name"`
+	Driver        types.String `tfsdk:"driver"`
+	Mountpoint    types.String `tfsdk:"mountpoint"`
+	Labels        types.Map    `tfsdk:"labels"`
+	Options       types.Map    `tfsdk:"options"`
+	Size          types.Int64  `tfsdk:"size"`
+	Containers    types.List   `tfsdk:"containers"`
+}
+
+// Metadata returns the data source type name.
+func (d *VolumeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume"
+}
+
+// Schema defines the schema for the data source.
+func (d *VolumeDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches a single volume in a Dockhand environment by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The volume ID. Exactly one of `id` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The volume name. Exactly one of `id` or `name` must be set.",
+			},
+			"driver": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The volume driver.",
+			},
+			"mountpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The mount point of the volume on the host.",
+			},
+			"labels": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Labels for the volume.",
+			},
+			"options": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Driver-specific options.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size of the volume in bytes, when reported by the driver.",
+			},
+			"containers": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the containers using the volume.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *VolumeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *VolumeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config VolumeDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	validateLookupKeys(config.ID, config.Name, "volume", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentID := config.EnvironmentID.ValueString()
+
+	var volume *client.Volume
+	if !config.ID.IsNull() {
+		v, err := d.client.GetVolume(environmentID, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading volume",
+				"Could not read volume: "+err.Error(),
+			)
+			return
+		}
+		volume = v
+	} else {
+		volumes, err := d.client.ListVolumes(environmentID, &client.ListFilter{Name: config.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading volumes",
+				"Could not read volumes: "+err.Error(),
+			)
+			return
+		}
+
+		volume = lookupByName(volumes, config.Name.ValueString(), func(v client.Volume) string { return v.Name }, "volume", &resp.Diagnostics)
+		if volume == nil {
+			return
+		}
+	}
+
+	state := VolumeDataSourceModel{
+		ID:            types.StringValue(volume.ID),
+		EnvironmentID: config.EnvironmentID,
+		Name:          types.StringValue(volume.Name),
+		Driver:        types.StringValue(volume.Driver),
+		Mountpoint:    types.StringValue(volume.Mountpoint),
+		Size:          types.Int64Value(volume.Size),
+	}
+
+	state.Labels, diags = types.MapValueFrom(ctx, types.StringType, volume.Labels)
+	resp.Diagnostics.Append(diags...)
+
+	state.Options, diags = types.MapValueFrom(ctx, types.StringType, volume.Options)
+	resp.Diagnostics.Append(diags...)
+
+	state.Containers, diags = types.ListValueFrom(ctx, types.StringType, volume.Containers)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Read volume data source", map[string]any{"id": volume.ID})
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
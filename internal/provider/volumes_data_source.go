@@ -2,34 +2,213 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ramorous/terraform-provider-dockhand/internal/client"
 )
 
+// Ensure the implementation defined in this package is a datasource.DataSource
 var _ datasource.DataSource = &VolumesDataSource{}
 
+// NewVolumesDataSource is a helper function to simplify the provider implementation.
 func NewVolumesDataSource() datasource.DataSource {
 	return &VolumesDataSource{}
 }
 
+// VolumesDataSource is the data source implementation.
 type VolumesDataSource struct {
 	client *client.Client
 }
 
+// VolumesDataSourceModel describes the data source data model.
+type VolumesDataSourceModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	Filter        types.Object `tfsdk:"filter"`
+	Volumes       types.List   `tfsdk:"volumes"`
+	ID            types.String `tfsdk:"id"`
+}
+
+// VolumesFilterModel describes the filter attribute of the data source.
+type VolumesFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+	Driver    types.String `tfsdk:"driver"`
+}
+
+// VolumeData describes a volume in the data source
+type VolumeData struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Driver     types.String `tfsdk:"driver"`
+	Mountpoint types.String `tfsdk:"mountpoint"`
+	Labels     types.Map    `tfsdk:"labels"`
+	Size       types.Int64  `tfsdk:"size"`
+}
+
+// Metadata returns the data source type name.
 func (d *VolumesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_volumes"
 }
 
+// Schema defines the schema for the data source.
 func (d *VolumesDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Fetches a list of volumes in a Dockhand environment.",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The environment ID.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The data source ID.",
+			},
+			"filter": listFilterAttribute(map[string]schema.Attribute{
+				"driver": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Only include volumes using this driver.",
+				},
+			}),
+			"volumes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of volumes.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The volume ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The volume name.",
+						},
+						"driver": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The volume driver.",
+						},
+						"mountpoint": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The mount point of the volume on the host.",
+						},
+						"labels": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The labels.",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the volume in bytes, when reported by the driver.",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
+// Configure adds the provider configured client to the data source.
 func (d *VolumesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
 }
 
+// Read refreshes the Terraform state with the latest data.
 func (d *VolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config VolumesDataSourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filterModel VolumesFilterModel
+	if !config.Filter.IsNull() {
+		resp.Diagnostics.Append(config.Filter.As(ctx, &filterModel, basetypes.ObjectAsOptions{})...)
+	}
+
+	filter := newListFilter(ctx, filterModel.NameRegex, filterModel.Labels, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get volumes
+	volumes, err := d.client.ListVolumes(config.EnvironmentID.ValueString(), &client.ListFilter{Labels: filter.labels})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading volumes",
+			"Could not read volumes: "+err.Error(),
+		)
+		return
+	}
+
+	// Convert to Terraform types
+	volumesList := []VolumeData{}
+	for _, v := range volumes {
+		if !filter.matchName(v.Name) || !filter.matchLabels(v.Labels) {
+			continue
+		}
+		if driver := filterModel.Driver.ValueString(); driver != "" && v.Driver != driver {
+			continue
+		}
+
+		item := VolumeData{
+			ID:         types.StringValue(v.ID),
+			Name:       types.StringValue(v.Name),
+			Driver:     types.StringValue(v.Driver),
+			Mountpoint: types.StringValue(v.Mountpoint),
+			Size:       types.Int64Value(v.Size),
+		}
+
+		item.Labels, diags = types.MapValueFrom(ctx, types.StringType, v.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		volumesList = append(volumesList, item)
+	}
+
+	volumesValue, diags := types.ListValueFrom(ctx, types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":         types.StringType,
+			"name":       types.StringType,
+			"driver":     types.StringType,
+			"mountpoint": types.StringType,
+			"labels":     types.MapType{ElemType: types.StringType},
+			"size":       types.Int64Type,
+		},
+	}, volumesList)
+	resp.Diagnostics.Append(diags...)
+
+	// Set data
+	state := VolumesDataSourceModel{
+		EnvironmentID: config.EnvironmentID,
+		Filter:        config.Filter,
+		Volumes:       volumesValue,
+		ID:            types.StringValue(config.EnvironmentID.ValueString()),
+	}
+
+	tflog.Trace(ctx, "Read volumes data source")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
@@ -3,9 +3,13 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/ramorous/terraform-provider-dockhand/internal/compose"
 	"github.com/ramorous/terraform-provider-dockhand/internal/provider"
 )
 
@@ -20,10 +24,21 @@ var (
 
 func main() {
 	var debug bool
+	var convertCompose, environmentID string
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.StringVar(&convertCompose, "convert-compose", "", "convert a compose file (- for stdin) into dockhand_network, dockhand_volume and dockhand_container resources printed as HCL, then exit")
+	flag.StringVar(&environmentID, "environment-id", "var.environment_id", "HCL expression for the environment_id of converted resources")
 	flag.Parse()
 
+	if convertCompose != "" {
+		if err := convert(convertCompose, environmentID); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/ramorous/dockhand",
 		Debug:   debug,
@@ -34,3 +49,30 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// convert prints the resources equivalent to a compose file on stdout, and
+// the compose features that were not converted on stderr.
+func convert(path, environmentID string) error {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := compose.Convert(content, compose.ConvertOptions{EnvironmentID: environmentID})
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(result.HCL)
+	for _, issue := range result.Unsupported {
+		fmt.Fprintln(os.Stderr, "warning: not converted:", issue.Error())
+	}
+
+	return nil
+}